@@ -0,0 +1,186 @@
+package reflecttype
+
+import (
+	"go/token"
+	"go/types"
+	"reflect"
+)
+
+// Following interfaces are method sets changing how validators handle a value, i.e. validate.Comparable,
+// validate.Lengther, encoding.TextMarshaler, and fmt.Stringer.
+var (
+	comparableInterface    = newInterface("CompareTo", []types.Type{types.Typ[types.String]}, []types.Type{types.Typ[types.Int], types.Universe.Lookup("error").Type()})
+	lengtherInterface      = newInterface("Len", nil, []types.Type{types.Typ[types.Int]})
+	textMarshalerInterface = newInterface("MarshalText", nil, []types.Type{types.NewSlice(types.Typ[types.Byte]), types.Universe.Lookup("error").Type()})
+	stringerInterface      = newInterface("String", nil, []types.Type{types.Typ[types.String]})
+)
+
+// Following types stand in for named types implementing validate.Comparable, a kind of a named type is kept.
+type (
+	comparableString string
+	comparableInt    int64
+	comparableUint   uint64
+	comparableFloat  float64
+	comparableSlice  []interface{}
+	comparableArray  [1]interface{}
+	comparableMap    map[interface{}]interface{}
+	comparableStruct struct{}
+)
+
+func (comparableString) CompareTo(string) (int, error) { return 0, nil }
+func (comparableInt) CompareTo(string) (int, error)    { return 0, nil }
+func (comparableUint) CompareTo(string) (int, error)   { return 0, nil }
+func (comparableFloat) CompareTo(string) (int, error)  { return 0, nil }
+func (comparableSlice) CompareTo(string) (int, error)  { return 0, nil }
+func (comparableArray) CompareTo(string) (int, error)  { return 0, nil }
+func (comparableMap) CompareTo(string) (int, error)    { return 0, nil }
+func (comparableStruct) CompareTo(string) (int, error) { return 0, nil }
+
+// Following types stand in for named types implementing validate.Lengther, a kind of a named type is kept.
+type (
+	lengtherString string
+	lengtherInt    int64
+	lengtherUint   uint64
+	lengtherFloat  float64
+	lengtherSlice  []interface{}
+	lengtherArray  [1]interface{}
+	lengtherMap    map[interface{}]interface{}
+	lengtherStruct struct{}
+)
+
+func (lengtherString) Len() int { return 0 }
+func (lengtherInt) Len() int    { return 0 }
+func (lengtherUint) Len() int   { return 0 }
+func (lengtherFloat) Len() int  { return 0 }
+func (lengtherSlice) Len() int  { return 0 }
+func (lengtherArray) Len() int  { return 0 }
+func (lengtherMap) Len() int    { return 0 }
+func (lengtherStruct) Len() int { return 0 }
+
+// Following types stand in for named types implementing encoding.TextMarshaler or fmt.Stringer, a kind of a named type
+// is kept. Strings are not marshaled by validators, so they have no stand-in type.
+type (
+	textBool   bool
+	textInt    int64
+	textUint   uint64
+	textFloat  float64
+	textSlice  []interface{}
+	textArray  [1]interface{}
+	textMap    map[interface{}]interface{}
+	textStruct struct{}
+)
+
+func (textBool) MarshalText() ([]byte, error)   { return nil, nil }
+func (textInt) MarshalText() ([]byte, error)    { return nil, nil }
+func (textUint) MarshalText() ([]byte, error)   { return nil, nil }
+func (textFloat) MarshalText() ([]byte, error)  { return nil, nil }
+func (textSlice) MarshalText() ([]byte, error)  { return nil, nil }
+func (textArray) MarshalText() ([]byte, error)  { return nil, nil }
+func (textMap) MarshalText() ([]byte, error)    { return nil, nil }
+func (textStruct) MarshalText() ([]byte, error) { return nil, nil }
+
+// methodTypes are stand-in types of method sets by a kind, the first implemented method set is used.
+// Kinds which are missing use a struct stand-in type, strings are left as they are if they have no stand-in type.
+var methodTypes = []struct {
+	ifaces []*types.Interface
+	kinds  map[reflect.Kind]reflect.Type
+}{
+	{[]*types.Interface{comparableInterface}, map[reflect.Kind]reflect.Type{
+		reflect.String:  reflect.TypeOf(comparableString("")),
+		reflect.Int:     reflect.TypeOf(comparableInt(0)),
+		reflect.Uint:    reflect.TypeOf(comparableUint(0)),
+		reflect.Float64: reflect.TypeOf(comparableFloat(0)),
+		reflect.Slice:   reflect.TypeOf(comparableSlice(nil)),
+		reflect.Array:   reflect.TypeOf(comparableArray{}),
+		reflect.Map:     reflect.TypeOf(comparableMap(nil)),
+		reflect.Struct:  reflect.TypeOf(comparableStruct{}),
+	}},
+	{[]*types.Interface{lengtherInterface}, map[reflect.Kind]reflect.Type{
+		reflect.String:  reflect.TypeOf(lengtherString("")),
+		reflect.Int:     reflect.TypeOf(lengtherInt(0)),
+		reflect.Uint:    reflect.TypeOf(lengtherUint(0)),
+		reflect.Float64: reflect.TypeOf(lengtherFloat(0)),
+		reflect.Slice:   reflect.TypeOf(lengtherSlice(nil)),
+		reflect.Array:   reflect.TypeOf(lengtherArray{}),
+		reflect.Map:     reflect.TypeOf(lengtherMap(nil)),
+		reflect.Struct:  reflect.TypeOf(lengtherStruct{}),
+	}},
+	{[]*types.Interface{textMarshalerInterface, stringerInterface}, map[reflect.Kind]reflect.Type{
+		reflect.Bool:    reflect.TypeOf(textBool(false)),
+		reflect.Int:     reflect.TypeOf(textInt(0)),
+		reflect.Uint:    reflect.TypeOf(textUint(0)),
+		reflect.Float64: reflect.TypeOf(textFloat(0)),
+		reflect.Slice:   reflect.TypeOf(textSlice(nil)),
+		reflect.Array:   reflect.TypeOf(textArray{}),
+		reflect.Map:     reflect.TypeOf(textMap(nil)),
+		reflect.Struct:  reflect.TypeOf(textStruct{}),
+	}},
+}
+
+// newInterface creates an interface of a single method
+func newInterface(name string, params []types.Type, results []types.Type) *types.Interface {
+	signature := types.NewSignature(nil, newTuple(params), newTuple(results), false)
+	method := types.NewFunc(token.NoPos, nil, name, signature)
+
+	return types.NewInterfaceType([]*types.Func{method}, nil).Complete()
+}
+
+// newTuple creates a tuple of unnamed variables of given types
+func newTuple(typs []types.Type) *types.Tuple {
+	vars := make([]*types.Var, len(typs))
+	for i, typ := range typs {
+		vars[i] = types.NewParam(token.NoPos, nil, "", typ)
+	}
+
+	return types.NewTuple(vars...)
+}
+
+// methodType gets a stand-in type of a named type implementing a method set used by validators or nil.
+// A type implements a method set if the type or a pointer to it implements it.
+func methodType(typ *types.Named) reflect.Type {
+	for _, m := range methodTypes {
+		if !implements(typ, m.ifaces) {
+			continue
+		}
+
+		underlying := Of(typ.Underlying())
+		if underlying == nil {
+			return m.kinds[reflect.Struct]
+		}
+
+		kind := underlying.Kind()
+		if kind == reflect.String {
+			if standIn, ok := m.kinds[kind]; ok {
+				return standIn
+			}
+			return nil
+		}
+
+		switch kind {
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			kind = reflect.Int
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			kind = reflect.Uint
+		case reflect.Float32:
+			kind = reflect.Float64
+		}
+
+		if standIn, ok := m.kinds[kind]; ok {
+			return standIn
+		}
+		return m.kinds[reflect.Struct]
+	}
+
+	return nil
+}
+
+// implements checks if a type or a pointer to it implements any of interfaces
+func implements(typ types.Type, ifaces []*types.Interface) bool {
+	for _, iface := range ifaces {
+		if types.Implements(typ, iface) || types.Implements(types.NewPointer(typ), iface) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,176 @@
+// Package reflecttype converts types of the go/types package into reflect types,
+// so tags could be checked by validate without running a program.
+package reflecttype
+
+import (
+	"encoding/json"
+	"go/types"
+	"math/big"
+	"reflect"
+	"time"
+)
+
+// Following types are used by validators.
+var (
+	durationType  = reflect.TypeOf(time.Duration(0))
+	timeType      = reflect.TypeOf(time.Time{})
+	structType    = reflect.TypeOf(struct{}{})
+	interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+)
+
+// namedTypes are named types used by validators by a package path and a name
+var namedTypes = map[string]map[string]reflect.Type{
+	"time": {
+		"Duration": durationType,
+		"Time":     timeType,
+	},
+	"math/big": {
+		"Int":   reflect.TypeOf(big.Int{}),
+		"Float": reflect.TypeOf(big.Float{}),
+		"Rat":   reflect.TypeOf(big.Rat{}),
+	},
+	"encoding/json": {
+		"Number": reflect.TypeOf(json.Number("")),
+	},
+}
+
+// Of converts a type into a reflect type of the same kind.
+// Named types used by validators are converted as is, named types implementing validate.Comparable,
+// validate.Lengther, encoding.TextMarshaler, or fmt.Stringer become a stand-in type of the same kind,
+// other structs become an empty struct.
+// It returns nil if a type could not be converted.
+func Of(typ types.Type) reflect.Type {
+	return of(typ, 0)
+}
+
+// StructOf converts a struct into a reflect struct having fields of the same names, so fields referenced
+// by cross-field validators and conditions could be found. Structs of fields are converted the same way
+// up to a given depth, i.e. a number of dots in a path to a field, deeper structs become an empty struct.
+// Tags are not converted, blank fields are skipped, and fields of channels and functions become an empty interface.
+// It returns nil if a struct could not be converted, e.g. a type of a field is not valid or reflect.StructOf
+// does not support a field, such as an unexported field in older versions of Go.
+func StructOf(typ *types.Struct, depth int) (structOf reflect.Type) {
+	fields := make([]reflect.StructField, 0, typ.NumFields())
+	for i := 0; i < typ.NumFields(); i++ {
+		v := typ.Field(i)
+		if v.Name() == "_" {
+			continue
+		}
+
+		fieldType := of(v.Type(), depth)
+		if fieldType == nil {
+			switch v.Type().Underlying().(type) {
+			case *types.Chan, *types.Signature:
+				fieldType = interfaceType
+			default:
+				return nil
+			}
+		}
+
+		field := reflect.StructField{
+			Name:      v.Name(),
+			Type:      fieldType,
+			Anonymous: v.Anonymous() && fieldType.NumMethod() == 0,
+		}
+		if !v.Exported() && v.Pkg() != nil {
+			field.PkgPath = v.Pkg().Path()
+		}
+		fields = append(fields, field)
+	}
+
+	defer func() {
+		if recover() != nil {
+			structOf = nil
+		}
+	}()
+
+	return reflect.StructOf(fields)
+}
+
+// of converts a type into a reflect type, structs are converted by StructOf up to a given depth
+func of(typ types.Type, depth int) reflect.Type {
+	switch t := typ.(type) {
+	case *types.Named:
+		if obj := t.Obj(); obj.Pkg() != nil {
+			if named, ok := namedTypes[obj.Pkg().Path()][obj.Name()]; ok {
+				return named
+			}
+		}
+		if standIn := methodType(t); standIn != nil {
+			return standIn
+		}
+		return of(t.Underlying(), depth)
+	case *types.Basic:
+		return basicType(t)
+	case *types.Pointer:
+		if elem := of(t.Elem(), depth); elem != nil {
+			return reflect.PtrTo(elem)
+		}
+	case *types.Slice:
+		if elem := Of(t.Elem()); elem != nil {
+			return reflect.SliceOf(elem)
+		}
+	case *types.Array:
+		if elem := Of(t.Elem()); elem != nil {
+			return reflect.ArrayOf(int(t.Len()), elem)
+		}
+	case *types.Map:
+		key, elem := Of(t.Key()), Of(t.Elem())
+		if key != nil && elem != nil && key.Comparable() {
+			return reflect.MapOf(key, elem)
+		}
+	case *types.Struct:
+		if depth > 0 {
+			return StructOf(t, depth-1)
+		}
+		return structType
+	case *types.Interface:
+		return interfaceType
+	}
+
+	return nil
+}
+
+// basicType converts a basic type into a reflect type
+func basicType(typ *types.Basic) reflect.Type {
+	switch typ.Kind() {
+	case types.Bool:
+		return reflect.TypeOf(false)
+	case types.Int:
+		return reflect.TypeOf(int(0))
+	case types.Int8:
+		return reflect.TypeOf(int8(0))
+	case types.Int16:
+		return reflect.TypeOf(int16(0))
+	case types.Int32:
+		return reflect.TypeOf(int32(0))
+	case types.Int64:
+		return reflect.TypeOf(int64(0))
+	case types.Uint:
+		return reflect.TypeOf(uint(0))
+	case types.Uint8:
+		return reflect.TypeOf(uint8(0))
+	case types.Uint16:
+		return reflect.TypeOf(uint16(0))
+	case types.Uint32:
+		return reflect.TypeOf(uint32(0))
+	case types.Uint64:
+		return reflect.TypeOf(uint64(0))
+	case types.Uintptr:
+		return reflect.TypeOf(uintptr(0))
+	case types.Float32:
+		return reflect.TypeOf(float32(0))
+	case types.Float64:
+		return reflect.TypeOf(float64(0))
+	case types.Complex64:
+		return reflect.TypeOf(complex64(0))
+	case types.Complex128:
+		return reflect.TypeOf(complex128(0))
+	case types.String:
+		return reflect.TypeOf("")
+	case types.UnsafePointer:
+		return reflect.TypeOf(uintptr(0))
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+// Package example is used to test code generated by validategen.
+package example
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	validate "gopkg.in/dealancer/validate.v2"
+)
+
+//go:generate go run gopkg.in/dealancer/validate.v2/cmd/validategen -type=Order
+
+func init() {
+	validate.RegisterFormat("sku", func(value string) bool {
+		return strings.HasPrefix(value, "SKU-")
+	})
+}
+
+// Status is a status of an order.
+type Status string
+
+// Order is an order.
+type Order struct {
+	ID       int64             `validate:"gt=0"`
+	Status   Status            `validate:"one_of=new,paid,shipped"`
+	Email    string            `validate:"empty=true | format=email"`
+	Timeout  time.Duration     `validate:"gte=1s & lte=1m"`
+	Placed   time.Time         `validate:"lte=now & gte=2000-01-01 | eq=0001-01-01T00:00:00Z"`
+	Expires  *time.Time        `validate:"nil=true | future=true"`
+	Price    float64           `validate:"gte=0.01"`
+	Quantity uint              `validate:"gte=1 & lte=100 | eq=1000"`
+	Reserved uint              `validate:"lte_field=Quantity"`
+	Amount   json.Number       `validate:"empty=true | gte=0.01 & lte=100000000000000000000000"`
+	Tokens   *big.Int          `validate:"nil=true | one_of=1,1000000000000000000000000"`
+	Release  Release           `validate:"empty=true | gte=1.2 & lt=2"`
+	Packages Packages          `validate:"lte=2"`
+	Discount int               `validate:"(gte=0 & lte=50 | eq=100) & ne=13"`
+	Retries  int               `validate:"gte=x | gte=0"`
+	Tags     []string          `validate:"lte=3 > empty=false & lte=10"`
+	Labels   map[string]string `validate:"lte=2 [format=alnum] > empty=false"`
+	Counts   map[int]*int      `validate:"lte=10 [gte=0] > nil=false > gte=1"`
+	Codes    [2]string         `validate:"> format=numeric"`
+	SKU      string            `validate:"format=sku"`
+	Sep      string            `validate:"one_of=',','|',' ','>'"`
+	Nickname string            `validate:"!one_of=root,admin & !(empty=false & format=email)"`
+	Version  string            `validate:"empty=true | prefix=v & !is=v0 & before=v9"`
+	Host     string            `validate:"empty=true | suffix_fold=.Example.com & excludes=' '"`
+	Scheme   string            `validate:"empty=true | is_fold=HTTPS | prefix_fold=ws & !contains_fold=Unsafe"`
+	Addr     net.IP            `validate:"empty=true | format=ipv4"`
+	Locale   string            `validate:"empty=true | format='alpha' & lte='5'"`
+	Tracking string            `validate:"required_if=Status:shipped"`
+	Coupon   string            `validate:"if=Status:new ? empty=true | format=alnum"`
+	Items    []Item            `validate:"empty=false"`
+	Shipping *Address          `validate:"nil=false"`
+	Billing  *Address
+	Customer Customer
+	Referrer *Customer
+	Secret   Secret
+	Meta     interface{}
+	Parent   *Order
+	note     string           `validate:"lte=5"`
+	notes    sort.StringSlice `validate:"lte=2"`
+	closed   *time.Time       `validate:"nil=true | past=true"`
+	secret   Secret
+	lease    Lease
+}
+
+// Release is a version of a release compared by its numeric parts, e.g. 1.10 is greater than 1.9.
+type Release string
+
+// CompareTo compares a release with a version.
+func (r Release) CompareTo(literal string) (int, error) {
+	version, err := parseRelease(literal)
+	if err != nil {
+		return 0, validate.ErrSyntax
+	}
+	release, err := parseRelease(string(r))
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(release) || i < len(version); i++ {
+		var a, b int
+		if i < len(release) {
+			a = release[i]
+		}
+		if i < len(version) {
+			b = version[i]
+		}
+		if a != b {
+			return a - b, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// parseRelease parses numeric parts of a release.
+func parseRelease(release string) ([]int, error) {
+	var parts []int
+	for _, part := range strings.Split(release, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, errors.New("release is not valid")
+		}
+		parts = append(parts, n)
+	}
+
+	return parts, nil
+}
+
+// Packages is a comma separated list of packages.
+type Packages string
+
+// Len gets a count of packages.
+func (p Packages) Len() int {
+	if p == "" {
+		return 0
+	}
+
+	return strings.Count(string(p), ",") + 1
+}
+
+// Item is an item of an order.
+type Item struct {
+	Name string `validate:"gte=1"`
+	Qty  int    `validate:"gt=0"`
+}
+
+// Address is an address.
+type Address struct {
+	City string `validate:"empty=false"`
+	Zip  string `validate:"format=numeric & eq=5"`
+}
+
+// Validate is a custom validator of an address.
+func (a Address) Validate() error {
+	if a.City == "Nowhere" {
+		return errors.New("city does not exist")
+	}
+
+	return nil
+}
+
+// Lease is a period of an order.
+type Lease struct {
+	Start time.Time
+	End   *time.Time `validate:"nil=true | gt_field=Start"`
+}
+
+// Secret is a value validated by a custom validator.
+type Secret struct {
+	Value string
+}
+
+// Validate is a custom validator of a secret.
+func (s Secret) Validate() error {
+	if s.Value == "bad" {
+		return errors.New("secret is bad")
+	}
+
+	return nil
+}
+
+// Customer is a customer validated by a custom validator using a context.
+type Customer struct {
+	Name string `validate:"empty=false"`
+}
+
+// ValidateContext is a custom validator of a customer.
+func (c *Customer) ValidateContext(ctx context.Context) error {
+	if c != nil && c.Name == "blocked" {
+		return errors.New("customer is blocked")
+	}
+
+	return ctx.Err()
+}
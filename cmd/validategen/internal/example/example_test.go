@@ -0,0 +1,187 @@
+package example
+
+import (
+	"math/big"
+	"net"
+	"sort"
+	"testing"
+	"time"
+
+	validate "gopkg.in/dealancer/validate.v2"
+)
+
+var _ validate.GeneratedValidator = Order{}
+
+func validOrder() Order {
+	one := 1
+
+	return Order{
+		ID:       1,
+		Status:   "new",
+		Timeout:  time.Second,
+		Price:    1,
+		Quantity: 1,
+		Tags:     []string{"a", "b"},
+		Labels:   map[string]string{"a": "b", "c": "d"},
+		Counts:   map[int]*int{1: &one},
+		Codes:    [2]string{"1", "2"},
+		SKU:      "SKU-1",
+		Items:    []Item{{Name: "a", Qty: 1}},
+		Shipping: &Address{City: "Kyiv", Zip: "01001"},
+		Customer: Customer{Name: "Alice"},
+		Sep:      ",",
+	}
+}
+
+func TestCrossCheck(t *testing.T) {
+	zero, one, minusOne := 0, 1, -1
+
+	mutations := []func(o *Order){
+		func(o *Order) {},
+		func(o *Order) { o.ID = 0 },
+		func(o *Order) { o.Status = "lost" },
+		func(o *Order) { o.Email = "user@example.com" },
+		func(o *Order) { o.Email = "user" },
+		func(o *Order) { o.Timeout = time.Hour },
+		func(o *Order) { o.Timeout = 0 },
+		func(o *Order) { o.Placed = time.Now().Add(-time.Hour) },
+		func(o *Order) { o.Placed = time.Now().Add(time.Hour) },
+		func(o *Order) { o.Placed = time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC) },
+		func(o *Order) { o.Expires = &time.Time{} },
+		func(o *Order) { future := time.Now().Add(time.Hour); o.Expires = &future },
+		func(o *Order) { o.Price = 0 },
+		func(o *Order) { o.Quantity = 1000 },
+		func(o *Order) { o.Quantity = 101 },
+		func(o *Order) { o.Reserved = 2 },
+		func(o *Order) { o.Amount = "0.001" },
+		func(o *Order) { o.Amount = "1e23" },
+		func(o *Order) { o.Amount = "1e24" },
+		func(o *Order) { o.Amount = "x" },
+		func(o *Order) { o.Tokens = big.NewInt(1) },
+		func(o *Order) { o.Tokens = big.NewInt(2) },
+		func(o *Order) { o.Release = "1.10" },
+		func(o *Order) { o.Release = "1.1" },
+		func(o *Order) { o.Release = "2.0.1" },
+		func(o *Order) { o.Release = "1.x" },
+		func(o *Order) { o.Packages = "a,b" },
+		func(o *Order) { o.Packages = "a,b,c" },
+		func(o *Order) { o.Tokens, _ = new(big.Int).SetString("1000000000000000000000000", 10) },
+		func(o *Order) { o.Discount = 13 },
+		func(o *Order) { o.Discount = 60 },
+		func(o *Order) { o.Discount = 100 },
+		func(o *Order) { o.Retries = -1 },
+		func(o *Order) { o.Tags = []string{"a", "", "b", "c"} },
+		func(o *Order) { o.Tags = []string{"a", "very long tag"} },
+		func(o *Order) { o.Labels = map[string]string{"b": "", "a!": "", "c": "d"} },
+		func(o *Order) { o.Counts = map[int]*int{-1: &one, 2: nil, 3: &zero, 4: &minusOne} },
+		func(o *Order) { o.Codes[1] = "x" },
+		func(o *Order) { o.SKU = "1" },
+		func(o *Order) { o.Sep = "|" },
+		func(o *Order) { o.Sep = ",," },
+		func(o *Order) { o.Nickname = "root" },
+		func(o *Order) { o.Nickname = "user@example.com" },
+		func(o *Order) { o.Nickname = "user" },
+		func(o *Order) { o.Version = "v1.2" },
+		func(o *Order) { o.Version = "v0" },
+		func(o *Order) { o.Version = "1.2" },
+		func(o *Order) { o.Version = "v9.1" },
+		func(o *Order) { o.Host = "api.EXAMPLE.com" },
+		func(o *Order) { o.Host = "api example.com" },
+		func(o *Order) { o.Host = "example.org" },
+		func(o *Order) { o.Scheme = "Https" },
+		func(o *Order) { o.Scheme = "WSS" },
+		func(o *Order) { o.Scheme = "ws-unsafe" },
+		func(o *Order) { o.Scheme = "ftp" },
+		func(o *Order) { o.Scheme = "\u212aS" },
+		func(o *Order) { o.Scheme = "ws-un\u017fafe" },
+		func(o *Order) { o.Addr = net.IPv4(127, 0, 0, 1) },
+		func(o *Order) { o.Locale = "en" },
+		func(o *Order) { o.Locale = "en-US" },
+		func(o *Order) { o.Locale = "english" },
+		func(o *Order) { o.Status = "shipped" },
+		func(o *Order) { o.Coupon = "bad coupon" },
+		func(o *Order) { o.Status, o.Coupon = "paid", "bad coupon" },
+		func(o *Order) { o.Items = nil },
+		func(o *Order) { o.Items = []Item{{}, {Name: "a"}} },
+		func(o *Order) { o.Shipping = nil },
+		func(o *Order) { o.Shipping = &Address{City: "Nowhere", Zip: "1"} },
+		func(o *Order) { o.Billing = &Address{} },
+		func(o *Order) { o.Customer.Name = "" },
+		func(o *Order) { o.Customer.Name = "blocked" },
+		func(o *Order) { o.Referrer = &Customer{Name: "blocked"} },
+		func(o *Order) { o.Secret.Value = "bad" },
+		func(o *Order) { o.secret.Value = "bad" },
+		func(o *Order) { o.Meta = Secret{"bad"} },
+		func(o *Order) { o.Parent = &Order{ID: -1, Shipping: &Address{Zip: "abcde"}} },
+		func(o *Order) { o.note = "too long" },
+		func(o *Order) { past := time.Now().Add(-time.Hour); o.closed = &past },
+		func(o *Order) { o.notes = sort.StringSlice{"a", "b"} },
+		func(o *Order) { o.notes = sort.StringSlice{"a", "b", "c"} },
+		func(o *Order) { end := time.Now(); o.lease.End = &end },
+		func(o *Order) { *o = Order{} },
+	}
+
+	for i, mutate := range mutations {
+		order := validOrder()
+		mutate(&order)
+
+		if err := validate.CrossCheck(order); err != nil {
+			t.Errorf("mutation %d: %v", i, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	order := validOrder()
+	if err := order.Validate(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	order.Items[0].Qty = 0
+	err := order.Validate()
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	if _, ok := err.(validate.ErrorValidation); !ok {
+		t.Errorf("expected ErrorValidation, got %T", err)
+	}
+
+	if path := err.(validate.ErrorField).FieldPath().String(); path != "Items[0].Qty" {
+		t.Errorf("unexpected path %v", path)
+	}
+}
+
+func TestCrossCheckStringLength(t *testing.T) {
+	defer validate.SetStringLength(validate.StringLengthBytes)
+
+	for _, unit := range []validate.StringLength{validate.StringLengthBytes, validate.StringLengthRunes} {
+		validate.SetStringLength(unit)
+
+		for _, note := range []string{"прив", "привіт"} {
+			order := validOrder()
+			order.note = note
+
+			if err := validate.CrossCheck(order); err != nil {
+				t.Errorf("unit %v, note %q: %v", unit, note, err)
+			}
+		}
+	}
+}
+
+func TestCrossCheckTextMarshaling(t *testing.T) {
+	defer validate.SetTextMarshaling(false)
+
+	for _, enabled := range []bool{false, true} {
+		validate.SetTextMarshaling(enabled)
+
+		for _, addr := range []net.IP{nil, net.IPv4(127, 0, 0, 1), net.IPv6loopback, {1, 2, 3}} {
+			order := validOrder()
+			order.Addr = addr
+
+			if err := validate.CrossCheck(order); err != nil {
+				t.Errorf("enabled %v, addr %v: %v", enabled, addr, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,1021 @@
+// Command validategen generates validation code for struct types based on validate tags,
+// so values could be validated without reflection.
+//
+// It generates following methods for every given struct type and struct types it refers to in the same package:
+//
+//	func (t T) Validate() error
+//	func (t T) ValidateCustom() error
+//	func (t T) ValidateFields(s *validate.State) error
+//
+// Generated Validate returns the same errors validate.Validate does.
+// ValidateContext methods are called with a context of a validation run, ValidateCustom and Validate
+// use context.Background().
+// Validate is not generated if a type already has a custom Validate method, that method is called
+// by generated code instead, use -method to generate a method with another name in such a case.
+// Values which could not be validated without reflection, such as interfaces, values with custom validators
+// registered using validate.RegisterValidator or custom formats, are validated using reflection.
+// Generated code uses Go field names in errors and it does not handle built-in validators overridden
+// using validate.OverrideValidator.
+//
+// Usage:
+//
+//	//go:generate go run gopkg.in/dealancer/validate.v2/cmd/validategen -type=User,Address
+//
+// Flags:
+//
+//	-type string
+//		comma separated list of struct types, all struct types with tags are used by default
+//	-tag string
+//		name of the tag containing validators (default "validate")
+//	-method string
+//		name of the generated validation method (default "Validate")
+//	-output string
+//		output file name, <type>_validate.go is used by default
+//	-crosscheck
+//		make generated code check its results against validation using reflection and panic
+//		on any difference, use it when running tests
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	validate "gopkg.in/dealancer/validate.v2"
+	"gopkg.in/dealancer/validate.v2/cmd/internal/reflecttype"
+)
+
+// errUnsupported is returned when a value could not be validated without reflection
+var errUnsupported = errors.New("could not be validated without reflection")
+
+// generator generates validation code for struct types of a package
+type generator struct {
+	pkg        *types.Package
+	tag        string
+	method     string
+	crossCheck bool
+	validator  *validate.Validator
+	queue      []*types.Named
+	queued     map[*types.Named]bool
+	needed     map[neededKey]bool
+	sort       bool // generated code uses the sort package
+	context    bool // generated code uses the context package
+	strings    bool // generated code uses the strings package
+	buf        bytes.Buffer
+}
+
+func main() {
+	typeNames := flag.String("type", "", "comma separated list of struct types, all struct types with tags are used by default")
+	tag := flag.String("tag", validate.MasterTag, "name of the tag containing validators")
+	method := flag.String("method", "Validate", "name of the generated validation method")
+	output := flag.String("output", "", "output file name, <type>_validate.go is used by default")
+	crossCheck := flag.Bool("crosscheck", false, "make generated code check its results against validation using reflection")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: validategen [flags] [directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	names := splitList(*typeNames)
+	if *output == "" {
+		name := os.Getenv("GOPACKAGE")
+		if len(names) > 0 {
+			name = names[0]
+		}
+		if name == "" {
+			name = filepath.Base(dir)
+		}
+		*output = strings.ToLower(name) + "_validate.go"
+	}
+	if !filepath.IsAbs(*output) && filepath.Dir(*output) == "." {
+		*output = filepath.Join(dir, *output)
+	}
+
+	pkg, err := loadPackage(dir, *output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	g := newGenerator(pkg, *tag, *method, *crossCheck)
+	src, err := g.generate(names)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// splitList splits a comma separated list
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// loadPackage parses and type checks a package in a directory, test files and an output file are skipped.
+// Type errors are ignored, since other files may use methods declared in the output file.
+func loadPackage(dir string, output string) (*types.Package, error) {
+	fset := token.NewFileSet()
+	outputName := filepath.Base(output)
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") && info.Name() != outputName
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected a single package in %v, found %d", dir, len(pkgs))
+	}
+
+	var files []*ast.File
+	var name string
+	for name = range pkgs {
+		for _, file := range pkgs[name].Files {
+			files = append(files, file)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return fset.Position(files[i].Pos()).Filename < fset.Position(files[j].Pos()).Filename
+	})
+
+	config := types.Config{
+		Importer: importer.For("source", nil), // importer.ForCompiler requires Go 1.12
+		Error:    func(err error) {},
+	}
+	pkg, _ := config.Check(name, fset, files, nil)
+
+	return pkg, nil
+}
+
+// newGenerator creates a generator
+func newGenerator(pkg *types.Package, tag string, method string, crossCheck bool) *generator {
+	return &generator{
+		pkg:        pkg,
+		tag:        tag,
+		method:     method,
+		crossCheck: crossCheck,
+		validator:  validate.New(validate.WithTag(tag)),
+		queued:     make(map[*types.Named]bool),
+		needed:     make(map[neededKey]bool),
+	}
+}
+
+// generate generates validation code for given struct types and struct types they refer to
+func (g *generator) generate(names []string) ([]byte, error) {
+	if len(names) == 0 {
+		names = g.taggedStructs()
+	}
+
+	for _, name := range names {
+		named, ok := g.lookupStruct(name)
+		if !ok {
+			return nil, fmt.Errorf("could not find struct type %v", name)
+		}
+		g.enqueue(named)
+	}
+
+	if len(g.queue) == 0 {
+		return nil, fmt.Errorf("could not find struct types with %v tags", g.tag)
+	}
+
+	for i := 0; i < len(g.queue); i++ {
+		if err := g.generateStruct(g.queue[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	var header bytes.Buffer
+	fmt.Fprintf(&header, "// Code generated by validategen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&header, "package %v\n\n", g.pkg.Name())
+	fmt.Fprintf(&header, "import (\n")
+	if g.context {
+		fmt.Fprintf(&header, "\t\"context\"\n")
+	}
+	if g.sort {
+		fmt.Fprintf(&header, "\t\"sort\"\n")
+	}
+	if g.strings {
+		fmt.Fprintf(&header, "\t\"strings\"\n")
+	}
+	if g.context || g.sort || g.strings {
+		fmt.Fprintf(&header, "\n")
+	}
+	fmt.Fprintf(&header, "\tvalidate \"gopkg.in/dealancer/validate.v2\"\n")
+	fmt.Fprintf(&header, ")\n")
+
+	src := append(header.Bytes(), g.buf.Bytes()...)
+	formatted, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("could not format generated code: %v", err)
+	}
+
+	return formatted, nil
+}
+
+// taggedStructs gets names of struct types having fields with tags
+func (g *generator) taggedStructs() []string {
+	var names []string
+	for _, name := range g.pkg.Scope().Names() {
+		named, ok := g.lookupStruct(name)
+		if !ok {
+			continue
+		}
+		structType := named.Underlying().(*types.Struct)
+		for i := 0; i < structType.NumFields(); i++ {
+			if _, ok := reflect.StructTag(structType.Tag(i)).Lookup(g.tag); ok {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+
+	return names
+}
+
+// lookupStruct looks up a struct type declared in a package
+func (g *generator) lookupStruct(name string) (*types.Named, bool) {
+	typeName, ok := g.pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok || typeName.IsAlias() {
+		return nil, false
+	}
+
+	named, ok := typeName.Type().(*types.Named)
+	if !ok {
+		return nil, false
+	}
+
+	_, ok = named.Underlying().(*types.Struct)
+
+	return named, ok
+}
+
+// generatable checks if code for a struct type could be generated in this package
+func (g *generator) generatable(typ types.Type) (*types.Named, bool) {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() != g.pkg || named.Obj().Parent() != g.pkg.Scope() {
+		return nil, false
+	}
+
+	_, ok = named.Underlying().(*types.Struct)
+
+	return named, ok
+}
+
+// enqueue adds a struct type to the queue of types to generate code for
+func (g *generator) enqueue(named *types.Named) {
+	if !g.queued[named] {
+		g.queued[named] = true
+		g.queue = append(g.queue, named)
+	}
+}
+
+// hasMethod checks if a type or a pointer to it has a method with a given name, which returns only an error
+func hasMethod(typ types.Type, name string) bool {
+	for _, t := range []types.Type{typ, types.NewPointer(typ)} {
+		selection := types.NewMethodSet(t).Lookup(nil, name)
+		if selection == nil {
+			continue
+		}
+		signature := selection.Type().(*types.Signature)
+		if signature.Params().Len() == 0 && signature.Results().Len() == 1 &&
+			types.Identical(signature.Results().At(0).Type(), types.Universe.Lookup("error").Type()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasContextMethod checks if a type or a pointer to it has a ValidateContext method implementing validate.ContextValidator
+func hasContextMethod(typ types.Type) bool {
+	for _, t := range []types.Type{typ, types.NewPointer(typ)} {
+		selection := types.NewMethodSet(t).Lookup(nil, "ValidateContext")
+		if selection == nil {
+			continue
+		}
+		signature := selection.Type().(*types.Signature)
+		if signature.Params().Len() == 1 && signature.Results().Len() == 1 &&
+			types.TypeString(signature.Params().At(0).Type(), nil) == "context.Context" &&
+			types.Identical(signature.Results().At(0).Type(), types.Universe.Lookup("error").Type()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasComparisonMethod checks if a type or a pointer to it has a method used by comparison validators,
+// i.e. it may implement validate.Comparable or validate.Lengther
+func hasComparisonMethod(typ types.Type) bool {
+	for _, t := range []types.Type{typ, types.NewPointer(typ)} {
+		methods := types.NewMethodSet(t)
+		if methods.Lookup(nil, "CompareTo") != nil || methods.Lookup(nil, "Len") != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasTextMethod checks if a type or a pointer to it has a method used when text marshaling is enabled,
+// i.e. it may implement encoding.TextMarshaler or fmt.Stringer. Strings and time.Duration are not marshaled.
+func hasTextMethod(typ types.Type) bool {
+	if basic, ok := typ.Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 || isDuration(typ) {
+		return false
+	}
+
+	for _, t := range []types.Type{typ, types.NewPointer(typ)} {
+		methods := types.NewMethodSet(t)
+		if methods.Lookup(nil, "MarshalText") != nil || methods.Lookup(nil, "String") != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasGenerated checks if a type has validation code generated before
+func hasGenerated(typ types.Type) bool {
+	return types.NewMethodSet(typ).Lookup(nil, "ValidateFields") != nil
+}
+
+// hasMember checks if a type has a field or a method with a given name
+func hasMember(typ types.Type, name string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(typ), true, nil, name)
+
+	return obj != nil
+}
+
+// generateStruct generates validation methods of a struct type
+func (g *generator) generateStruct(named *types.Named) error {
+	name := named.Obj().Name()
+	for _, method := range []string{"ValidateCustom", "ValidateFields"} {
+		if hasMember(named, method) {
+			return fmt.Errorf("type %v already has %v", name, method)
+		}
+	}
+
+	hasValidate := hasMethod(named, "Validate")
+	generateMethod := !hasMember(named, g.method)
+	if !generateMethod {
+		fmt.Fprintf(os.Stderr, "validategen: type %v already has %v, use -method to generate a method with another name\n", name, g.method)
+	}
+
+	if generateMethod {
+		fmt.Fprintf(&g.buf, "\n// %v validates %v using %v tags.\n", g.method, name, g.tag)
+		fmt.Fprintf(&g.buf, "func (t %v) %v() error {\n", name, g.method)
+		if g.crossCheck {
+			fmt.Fprintf(&g.buf, "if err := validate.CrossCheck(t); err != nil {\npanic(err)\n}\n")
+		}
+		fmt.Fprintf(&g.buf, "s := validate.NewState()\n")
+		fmt.Fprintf(&g.buf, "if err := s.Report(t.ValidateCustom()); err != nil {\nreturn err\n}\n")
+		fmt.Fprintf(&g.buf, "return t.ValidateFields(s)\n")
+		fmt.Fprintf(&g.buf, "}\n")
+	}
+
+	fmt.Fprintf(&g.buf, "\n// ValidateCustom calls a custom validator of %v if it has one.\n", name)
+	fmt.Fprintf(&g.buf, "func (t %v) ValidateCustom() error {\n", name)
+	if hasContextMethod(named) {
+		g.context = true
+		fmt.Fprintf(&g.buf, "return t.ValidateContext(context.Background())\n")
+	} else if hasValidate {
+		fmt.Fprintf(&g.buf, "return t.Validate()\n")
+	} else {
+		fmt.Fprintf(&g.buf, "return nil\n")
+	}
+	fmt.Fprintf(&g.buf, "}\n")
+
+	w := &writer{g: g}
+	structType := named.Underlying().(*types.Struct)
+	for i := 0; i < structType.NumFields(); i++ {
+		if err := w.field(structType.Field(i), structType.Tag(i)); err != nil {
+			return fmt.Errorf("type %v: %v", name, err)
+		}
+	}
+
+	fmt.Fprintf(&g.buf, "\n// ValidateFields validates fields of %v using %v tags.\n", name, g.tag)
+	fmt.Fprintf(&g.buf, "func (t %v) ValidateFields(s *validate.State) error {\n", name)
+	g.buf.Write(w.buf.Bytes())
+	fmt.Fprintf(&g.buf, "return nil\n")
+	fmt.Fprintf(&g.buf, "}\n")
+
+	return nil
+}
+
+// customMethod gets a name of a method called as a custom validator of a value or an empty string
+func (g *generator) customMethod(typ types.Type) string {
+	elem := typ
+	if pointer, ok := typ.(*types.Pointer); ok {
+		elem = pointer.Elem()
+	}
+
+	if hasContextMethod(typ) {
+		return "ValidateContext"
+	}
+
+	if _, ok := g.generatable(elem); ok || hasMethod(typ, "ValidateCustom") {
+		return "ValidateCustom"
+	}
+
+	if hasMethod(typ, "Validate") {
+		return "Validate"
+	}
+
+	return ""
+}
+
+// needs checks if a value of a type should be validated
+func (g *generator) needs(typ types.Type, tag *validate.Tag, custom bool) bool {
+	if tag != nil {
+		return true
+	}
+
+	if custom && g.customMethod(typ) != "" {
+		return true
+	}
+
+	switch t := typ.Underlying().(type) {
+	case *types.Interface:
+		return custom
+	case *types.Struct:
+		key := neededKey{typ, custom}
+		if needed, ok := g.needed[key]; ok {
+			return needed
+		}
+		// A struct is not needed while its fields are checked to support recursive types,
+		// so only needed structs are remembered
+		g.needed[key] = false
+		for i := 0; i < t.NumFields(); i++ {
+			if reflect.StructTag(t.Tag(i)).Get(g.tag) != "" || g.needs(t.Field(i).Type(), nil, custom && t.Field(i).Exported()) {
+				g.needed[key] = true
+				return true
+			}
+		}
+		delete(g.needed, key)
+	case *types.Map:
+		return g.needs(t.Key(), nil, custom) || g.needs(t.Elem(), nil, custom)
+	case *types.Slice:
+		return g.needs(t.Elem(), nil, custom)
+	case *types.Array:
+		return g.needs(t.Elem(), nil, custom)
+	case *types.Pointer:
+		return g.needs(t.Elem(), nil, custom)
+	}
+
+	return false
+}
+
+// neededKey is a key of a struct type checked by needs
+type neededKey struct {
+	typ    types.Type
+	custom bool
+}
+
+// writer writes validation code of struct fields
+type writer struct {
+	g       *generator
+	buf     bytes.Buffer
+	vars    int
+	strings bool // written code uses the strings package
+}
+
+// newVar gets a name of a new local variable
+func (w *writer) newVar(prefix string) string {
+	name := prefix + strconv.Itoa(w.vars)
+	w.vars++
+
+	return name
+}
+
+// field writes validation code of a struct field
+func (w *writer) field(field *types.Var, structTag string) error {
+	validators := reflect.StructTag(structTag).Get(w.g.tag)
+
+	tag, err := validate.ParseTag(validators)
+	if err == nil && tag.Condition == nil && tag.Validators == nil && tag.Key == nil && tag.Elem == nil {
+		tag = nil
+	}
+
+	if err == nil && !w.g.needs(field.Type(), tag, field.Exported()) {
+		return nil
+	}
+
+	if field.Name() == "_" {
+		return fmt.Errorf("blank field could not be validated")
+	}
+
+	fmt.Fprintf(&w.buf, "s.PushField(%q, %v)\n", field.Name(), field.Exported())
+
+	// Generate code in a separate writer to fall back to reflection for unsupported values
+	native := &writer{g: w.g, vars: w.vars}
+	if err == nil {
+		if typ := reflecttype.Of(field.Type()); typ != nil {
+			err = w.g.validator.CheckTag(typ, validators)
+		} else {
+			err = errUnsupported
+		}
+	}
+	if err == nil {
+		name := native.newVar("v")
+		fmt.Fprintf(&native.buf, "%v := t.%v\n", name, field.Name())
+		err = native.value(field.Type(), tag, name, field.Exported())
+	}
+
+	if err == nil {
+		w.buf.Write(native.buf.Bytes())
+		w.vars = native.vars
+		w.g.strings = w.g.strings || native.strings
+	} else {
+		fmt.Fprintf(&w.buf, "if err := s.Validate(&t, &t.%v, %q); err != nil {\nreturn err\n}\n", field.Name(), validators)
+	}
+
+	fmt.Fprintf(&w.buf, "s.Pop()\n")
+
+	return nil
+}
+
+// value writes validation code of a value stored in a variable.
+// It returns errUnsupported if a value could not be validated without reflection.
+func (w *writer) value(typ types.Type, tag *validate.Tag, name string, custom bool) error {
+	// Call a custom validator
+	if _, ok := typ.Underlying().(*types.Interface); ok {
+		return errUnsupported
+	}
+	if method := w.g.customMethod(typ); custom && method != "" {
+		condition := "s.Exported()"
+		if _, ok := typ.(*types.Pointer); ok && method == "ValidateCustom" {
+			// Generated methods have value receivers, they are not called for nil pointers
+			condition += " && " + name + " != nil"
+		}
+		call := name + "." + method + "()"
+		if method == "ValidateContext" {
+			call = name + ".ValidateContext(s.Context())"
+		}
+		fmt.Fprintf(&w.buf, "if %v {\nif err := s.Report(%v); err != nil {\nreturn err\n}\n}\n", condition, call)
+	}
+
+	// Perform validators
+	if tag != nil && tag.Condition != nil {
+		return errUnsupported
+	}
+	if tag != nil && tag.Validators != nil {
+		if err := w.validators(typ, tag.Validators, name); err != nil {
+			return err
+		}
+	}
+
+	var key, elem *validate.Tag
+	if tag != nil {
+		key, elem = tag.Key, tag.Elem
+	}
+
+	// Dive one level deep into arrays and pointers
+	switch t := typ.Underlying().(type) {
+	case *types.Struct:
+		if !w.g.needs(typ, nil, custom) {
+			return nil
+		}
+		if hasGenerated(typ) {
+			// Use code generated by another file or package
+		} else if named, ok := w.g.generatable(typ); ok {
+			w.g.enqueue(named)
+		} else {
+			return errUnsupported
+		}
+		fmt.Fprintf(&w.buf, "if err := %v.ValidateFields(s); err != nil {\nreturn err\n}\n", name)
+	case *types.Map:
+		if !w.g.needs(t.Key(), key, custom) && !w.g.needs(t.Elem(), elem, custom) {
+			return nil
+		}
+		keyType, less, err := w.g.sortableKey(t.Key())
+		if err != nil {
+			return err
+		}
+		keys, k, v := w.newVar("keys"), w.newVar("k"), w.newVar("v")
+		fmt.Fprintf(&w.buf, "%v := make([]%v, 0, len(%v))\n", keys, keyType, name)
+		fmt.Fprintf(&w.buf, "for %v := range %v {\n%v = append(%v, %v)\n}\n", k, name, keys, keys, k)
+		fmt.Fprintf(&w.buf, "sort.Slice(%v, func(i, j int) bool {\nreturn %v\n})\n", keys, fmt.Sprintf(less, keys+"[i]", keys+"[j]"))
+		fmt.Fprintf(&w.buf, "for _, %v := range %v {\n", k, keys)
+		fmt.Fprintf(&w.buf, "s.PushKey(%v)\n", k)
+		if err := w.value(t.Key(), key, k, custom); err != nil {
+			return err
+		}
+		fmt.Fprintf(&w.buf, "%v := %v[%v]\n", v, name, k)
+		if err := w.value(t.Elem(), elem, v, custom); err != nil {
+			return err
+		}
+		fmt.Fprintf(&w.buf, "s.Pop()\n}\n")
+		w.g.sort = true
+	case *types.Slice, *types.Array:
+		elemType := t.(interface{ Elem() types.Type }).Elem()
+		if !w.g.needs(elemType, elem, custom) {
+			return nil
+		}
+		i, v := w.newVar("i"), w.newVar("v")
+		fmt.Fprintf(&w.buf, "for %v, %v := range %v {\n", i, v, name)
+		fmt.Fprintf(&w.buf, "s.PushIndex(%v)\n", i)
+		if err := w.value(elemType, elem, v, custom); err != nil {
+			return err
+		}
+		fmt.Fprintf(&w.buf, "s.Pop()\n}\n")
+	case *types.Pointer:
+		if !w.g.needs(t.Elem(), elem, custom) {
+			return nil
+		}
+		v := w.newVar("v")
+		fmt.Fprintf(&w.buf, "if %v != nil {\n", name)
+		fmt.Fprintf(&w.buf, "s.PushPointer()\n")
+		fmt.Fprintf(&w.buf, "%v := *%v\n", v, name)
+		if err := w.value(t.Elem(), elem, v, custom); err != nil {
+			return err
+		}
+		fmt.Fprintf(&w.buf, "s.Pop()\n}\n")
+	}
+
+	return nil
+}
+
+// sortableKey gets a name of a map key type and a format of a comparison used to sort keys.
+// Keys with methods are not supported, since they may be rendered in a path differently.
+func (g *generator) sortableKey(typ types.Type) (string, string, error) {
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok || types.NewMethodSet(typ).Len() > 0 || types.NewMethodSet(types.NewPointer(typ)).Len() > 0 {
+		return "", "", errUnsupported
+	}
+
+	if named, ok := typ.(*types.Named); ok && named.Obj().Pkg() != g.pkg {
+		return "", "", errUnsupported
+	}
+
+	name := types.TypeString(typ, types.RelativeTo(g.pkg))
+
+	switch {
+	case basic.Info()&types.IsBoolean != 0:
+		return name, "!%v && %v", nil
+	case basic.Info()&types.IsOrdered != 0:
+		return name, "%v < %v", nil
+	}
+
+	return "", "", errUnsupported
+}
+
+// validators writes validation code of value validators.
+// Validators combined by & operator report the first failed validator, validators combined by | operator
+// report the last one, so an error of the validator which decided the result is reported.
+func (w *writer) validators(typ types.Type, expression *validate.TagExpression, name string) error {
+	condition, err := w.expression(typ, expression, name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(&w.buf, "if !(%v) {\n", condition)
+
+	// All operands of | operator failed, so the last one decides the result
+	for expression.Operator == '|' {
+		expression = expression.Operands[len(expression.Operands)-1]
+	}
+
+	if expression.Operator == 0 {
+		validator := expression.Validator
+		fmt.Fprintf(&w.buf, "if err := s.%v(%v, %q, %q); err != nil {\nreturn err\n}\n", fail(validator), name, validator.Type, validator.Value)
+	} else {
+		fmt.Fprintf(&w.buf, "var err error\n")
+		if err := w.failure(typ, expression, name); err != nil {
+			return err
+		}
+		fmt.Fprintf(&w.buf, "if err != nil {\nreturn err\n}\n")
+	}
+
+	fmt.Fprintf(&w.buf, "}\n")
+
+	return nil
+}
+
+// expression gets a condition of an expression, combined operands are put in parentheses
+func (w *writer) expression(typ types.Type, expression *validate.TagExpression, name string) (string, error) {
+	if expression.Operator == 0 {
+		validator := expression.Validator
+		condition, err := w.g.condition(typ, validator, name)
+		if err != nil {
+			return "", err
+		}
+		if validator.Negated {
+			condition = "!(" + condition + ")"
+		}
+		if stringConditions[validator.Type].strings {
+			w.strings = true
+		}
+		return condition, nil
+	}
+
+	conditions := make([]string, len(expression.Operands))
+	for i, operand := range expression.Operands {
+		condition, err := w.expression(typ, operand, name)
+		if err != nil {
+			return "", err
+		}
+		if operand.Operator != 0 {
+			condition = "(" + condition + ")"
+		}
+		conditions[i] = condition
+	}
+
+	operator := " && "
+	if expression.Operator == '|' {
+		operator = " || "
+	}
+
+	return strings.Join(conditions, operator), nil
+}
+
+// failure writes code assigning an error of a failed expression to err
+func (w *writer) failure(typ types.Type, expression *validate.TagExpression, name string) error {
+	switch expression.Operator {
+	case '&':
+		fmt.Fprintf(&w.buf, "switch {\n")
+		last := len(expression.Operands) - 1
+		for i, operand := range expression.Operands {
+			if i < last {
+				condition, err := w.expression(typ, operand, name)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(&w.buf, "case !(%v):\n", condition)
+			} else {
+				fmt.Fprintf(&w.buf, "default:\n")
+			}
+			if err := w.failure(typ, operand, name); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(&w.buf, "}\n")
+	case '|':
+		return w.failure(typ, expression.Operands[len(expression.Operands)-1], name)
+	default:
+		validator := expression.Validator
+		fmt.Fprintf(&w.buf, "err = s.%v(%v, %q, %q)\n", fail(validator), name, validator.Type, validator.Value)
+	}
+
+	return nil
+}
+
+// fail gets a name of a method of validate.State reporting a failed validator
+func fail(validator validate.TagValidator) string {
+	if validator.Negated {
+		return "FailNegated"
+	}
+
+	return "Fail"
+}
+
+// Following operators are used by comparison validators.
+var compareOperators = map[validate.ValidatorType]string{
+	validate.ValidatorEq:  "==",
+	validate.ValidatorNe:  "!=",
+	validate.ValidatorGt:  ">",
+	validate.ValidatorLt:  "<",
+	validate.ValidatorGte: ">=",
+	validate.ValidatorLte: "<=",
+}
+
+// stringCondition is a condition of a string validator, the first %v is a value and the second %v is a quoted token.
+// Case of a value and a token is folded by State.FoldCase if fold is set.
+type stringCondition struct {
+	format  string
+	fold    bool
+	strings bool
+}
+
+// Following conditions are used by string validators.
+var stringConditions = map[validate.ValidatorType]stringCondition{
+	validate.ValidatorIs:           {"%v == %v", false, false},
+	validate.ValidatorIsNot:        {"%v != %v", false, false},
+	validate.ValidatorBefore:       {"%v < %v", false, false},
+	validate.ValidatorAfter:        {"%v > %v", false, false},
+	validate.ValidatorContains:     {"strings.Contains(%v, %v)", false, true},
+	validate.ValidatorExcludes:     {"!strings.Contains(%v, %v)", false, true},
+	validate.ValidatorPrefix:       {"strings.HasPrefix(%v, %v)", false, true},
+	validate.ValidatorSuffix:       {"strings.HasSuffix(%v, %v)", false, true},
+	validate.ValidatorContainsAny:  {"strings.ContainsAny(%v, %v)", false, true},
+	validate.ValidatorExcludesAll:  {"!strings.ContainsAny(%v, %v)", false, true},
+	validate.ValidatorIsFold:       {"strings.EqualFold(%v, %v)", false, true},
+	validate.ValidatorIsNotFold:    {"!strings.EqualFold(%v, %v)", false, true},
+	validate.ValidatorContainsFold: {"strings.Contains(%v, %v)", true, true},
+	validate.ValidatorExcludesFold: {"!strings.Contains(%v, %v)", true, true},
+	validate.ValidatorPrefixFold:   {"strings.HasPrefix(%v, %v)", true, true},
+	validate.ValidatorSuffixFold:   {"strings.HasSuffix(%v, %v)", true, true},
+}
+
+// condition gets a condition of a validator which is true if a value is valid.
+// Validators are known to be applicable to a type, since tags are checked before.
+func (g *generator) condition(typ types.Type, validator validate.TagValidator, name string) (string, error) {
+	kind, number, err := g.operand(typ, name)
+	if err != nil {
+		return "", err
+	}
+
+	// Quoted values are left to reflection, tokens of one_of are unquoted by validate.ParseTokens
+	if validator.Type != validate.ValidatorOneOf && strings.ContainsAny(validator.Value, "'\"") {
+		return "", errUnsupported
+	}
+
+	if condition, ok := stringConditions[validator.Type]; ok {
+		if kind != kindString {
+			return "", errUnsupported
+		}
+		token := strconv.Quote(validator.Value)
+		if condition.fold {
+			return fmt.Sprintf(condition.format, "s.FoldCase("+number+")", "s.FoldCase("+token+")"), nil
+		}
+		return fmt.Sprintf(condition.format, number, token), nil
+	}
+
+	switch validator.Type {
+	case validate.ValidatorEq, validate.ValidatorNe, validate.ValidatorGt, validate.ValidatorLt, validate.ValidatorGte, validate.ValidatorLte:
+		operator := compareOperators[validator.Type]
+		if kind == kindString {
+			token, err := strconv.Atoi(validator.Value)
+			if err != nil {
+				return "", errUnsupported
+			}
+			return fmt.Sprintf("s.StringLength(%v) %v %d", number, operator, token), nil
+		}
+		if kind == kindLen {
+			token, err := strconv.Atoi(validator.Value)
+			if err != nil {
+				return "", errUnsupported
+			}
+			return fmt.Sprintf("len(%v) %v %d", name, operator, token), nil
+		}
+		token, err := g.token(kind, typ, validator.Value)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%v %v %v", number, operator, token), nil
+	case validate.ValidatorEmpty:
+		isEmpty, err := strconv.ParseBool(validator.Value)
+		if err != nil || kind != kindLen && kind != kindString {
+			return "", errUnsupported
+		}
+		if isEmpty {
+			return fmt.Sprintf("len(%v) == 0", name), nil
+		}
+		return fmt.Sprintf("len(%v) != 0", name), nil
+	case validate.ValidatorNil:
+		isNil, err := strconv.ParseBool(validator.Value)
+		if err != nil {
+			return "", errUnsupported
+		}
+		if _, ok := typ.Underlying().(*types.Pointer); !ok {
+			return "", errUnsupported
+		}
+		if isNil {
+			return fmt.Sprintf("%v == nil", name), nil
+		}
+		return fmt.Sprintf("%v != nil", name), nil
+	case validate.ValidatorOneOf:
+		var conditions []string
+		for _, value := range validate.ParseTokens(validator.Value) {
+			if kind == kindString {
+				conditions = append(conditions, fmt.Sprintf("%v == %q", number, value))
+				continue
+			}
+			token, err := g.token(kind, typ, value)
+			if err != nil {
+				return "", err
+			}
+			conditions = append(conditions, fmt.Sprintf("%v == %v", number, token))
+		}
+		if len(conditions) == 0 {
+			return "", errUnsupported
+		}
+		return "(" + strings.Join(conditions, " || ") + ")", nil
+	case validate.ValidatorFormat:
+		if kind != kindString {
+			return "", errUnsupported
+		}
+		return fmt.Sprintf("s.Format(%q, %v)", validator.Value, number), nil
+	}
+
+	return "", errUnsupported
+}
+
+// operandKind is a kind of a value used by validators
+type operandKind int
+
+// Following operand kinds are available.
+const (
+	kindInt operandKind = iota
+	kindUint
+	kindFloat
+	kindString
+	kindLen
+	kindOther
+)
+
+// operand gets a kind of a value and an expression converting it into a number or a string
+func (g *generator) operand(typ types.Type, name string) (operandKind, string, error) {
+	// Numbers of arbitrary precision and types compared by their methods are left to reflection
+	if isJSONNumber(typ) || hasComparisonMethod(typ) || hasTextMethod(typ) {
+		return kindOther, name, errUnsupported
+	}
+
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		info := t.Info()
+		switch {
+		case info&types.IsInteger != 0 && info&types.IsUnsigned != 0:
+			return kindUint, fmt.Sprintf("uint64(%v)", name), nil
+		case info&types.IsInteger != 0:
+			return kindInt, fmt.Sprintf("int64(%v)", name), nil
+		case info&types.IsFloat != 0:
+			return kindFloat, fmt.Sprintf("float64(%v)", name), nil
+		case info&types.IsString != 0:
+			return kindString, fmt.Sprintf("string(%v)", name), nil
+		}
+		return kindOther, name, errUnsupported
+	case *types.Map, *types.Slice, *types.Array:
+		return kindLen, name, nil
+	}
+
+	return kindOther, name, nil
+}
+
+// token gets a literal of a validator token of a number
+func (g *generator) token(kind operandKind, typ types.Type, value string) (string, error) {
+	switch kind {
+	case kindInt:
+		if isDuration(typ) {
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				return "", errUnsupported
+			}
+			return strconv.FormatInt(int64(duration), 10), nil
+		}
+		token, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "", errUnsupported
+		}
+		return strconv.FormatInt(token, 10), nil
+	case kindUint:
+		token, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return "", errUnsupported
+		}
+		return strconv.FormatUint(token, 10), nil
+	case kindFloat:
+		token, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsInf(token, 0) || math.IsNaN(token) {
+			return "", errUnsupported
+		}
+		return strconv.FormatFloat(token, 'g', -1, 64), nil
+	}
+
+	return "", errUnsupported
+}
+
+// isJSONNumber checks if a type is json.Number
+func isJSONNumber(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "encoding/json" && named.Obj().Name() == "Number"
+}
+
+// isDuration checks if a type is time.Duration
+func isDuration(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Duration"
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"go/types"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var (
+	examplePkg     *types.Package
+	examplePkgOnce sync.Once
+)
+
+// loadExample loads the example package once, since type checking is slow
+func loadExample(t *testing.T) *types.Package {
+	examplePkgOnce.Do(func() {
+		pkg, err := loadPackage(filepath.Join("internal", "example"), "order_validate.go")
+		if err != nil {
+			t.Fatal(err)
+		}
+		examplePkg = pkg
+	})
+
+	return examplePkg
+}
+
+func generateExample(t *testing.T, names []string, crossCheck bool) []byte {
+	pkg := loadExample(t)
+
+	src, err := newGenerator(pkg, "validate", "Validate", crossCheck).generate(names)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return src
+}
+
+func TestGenerate(t *testing.T) {
+	expected, err := ioutil.ReadFile(filepath.Join("internal", "example", "order_validate.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if src := generateExample(t, []string{"Order"}, false); !bytes.Equal(src, expected) {
+		t.Errorf("generated code differs from internal/example/order_validate.go, run go generate")
+	}
+}
+
+func TestGenerateCrossCheck(t *testing.T) {
+	src := generateExample(t, []string{"Item"}, true)
+
+	if !strings.Contains(string(src), "validate.CrossCheck(t)") {
+		t.Errorf("expected generated code to cross-check results")
+	}
+
+	if strings.Contains(string(src), "func (t Order)") {
+		t.Errorf("expected code generated only for Item")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	pkg := loadExample(t)
+
+	for _, name := range []string{"Unknown", "Status"} {
+		if _, err := newGenerator(pkg, "validate", "Validate", false).generate([]string{name}); err == nil {
+			t.Errorf("expected an error for type %v", name)
+		}
+	}
+
+	if _, err := newGenerator(pkg, "unknown", "Validate", false).generate(nil); err == nil {
+		t.Errorf("expected an error if there are no struct types with tags")
+	}
+}
@@ -0,0 +1,350 @@
+// Command validatelint checks validate tags of struct fields without running a program.
+//
+// It parses Go source files, finds struct fields with validate tags and checks them
+// using the same grammar and validators as the validate package does.
+// It reports syntax errors, unknown validator types, unknown formats, validators that could not be applied
+// to a type of a field, and fields referenced by cross-field validators, conditional validators, and guards
+// which could not be found.
+//
+// Usage:
+//
+//	validatelint [flags] [directories]
+//
+// Directories ending with /... are checked recursively, e.g. ./... checks the whole module.
+// The current directory is checked by default.
+// It exits with status 1 if there are problems, so it can be used in CI.
+//
+// Flags:
+//
+//	-tag string
+//		name of the tag containing validators (default "validate")
+//	-validators string
+//		comma separated list of custom validator types registered by a program
+//	-formats string
+//		comma separated list of custom formats registered by a program
+//	-text
+//		validate values implementing encoding.TextMarshaler or fmt.Stringer using their text,
+//		as validate.WithTextMarshaling does
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	validate "gopkg.in/dealancer/validate.v2"
+	"gopkg.in/dealancer/validate.v2/cmd/internal/reflecttype"
+)
+
+// problem is a problem found in a tag
+type problem struct {
+	pos   token.Position
+	field string
+	err   error
+}
+
+// String renders a problem.
+func (p problem) String() string {
+	return fmt.Sprintf("%v: field %v: %v", p.pos, p.field, p.err)
+}
+
+// linter checks tags of struct fields
+type linter struct {
+	fset      *token.FileSet
+	importer  types.Importer
+	validator *validate.Validator
+	tag       string
+}
+
+func main() {
+	tag := flag.String("tag", validate.MasterTag, "name of the tag containing validators")
+	validators := flag.String("validators", "", "comma separated list of custom validator types registered by a program")
+	formats := flag.String("formats", "", "comma separated list of custom formats registered by a program")
+	text := flag.Bool("text", false, "validate values implementing encoding.TextMarshaler or fmt.Stringer using their text")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: validatelint [flags] [directories]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	l, err := newLinter(*tag, splitList(*validators), splitList(*formats), *text)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	dirs, err := expandDirs(flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	var problems []problem
+	for _, dir := range dirs {
+		p, err := l.lintDir(dir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		problems = append(problems, p...)
+	}
+
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+}
+
+// newLinter creates a linter, custom validators and formats are treated as valid for any value.
+// Text enables text marshaling of values as validate.WithTextMarshaling does.
+func newLinter(tag string, validators []string, formats []string, text bool) (*linter, error) {
+	options := []validate.Option{validate.WithTag(tag)}
+	if text {
+		options = append(options, validate.WithTextMarshaling())
+	}
+
+	fset := token.NewFileSet()
+	l := &linter{
+		fset:      fset,
+		importer:  importer.For("source", nil), // importer.ForCompiler requires Go 1.12
+		validator: validate.New(options...),
+		tag:       tag,
+	}
+
+	for _, validatorType := range validators {
+		if err := l.validator.RegisterValidator(validate.ValidatorType(validatorType), func(value reflect.Value, validator string) error {
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, formatType := range formats {
+		if err := l.validator.RegisterFormat(validate.FormatType(formatType), func(value string) bool {
+			return true
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+// splitList splits a comma separated list
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// expandDirs expands directories ending with /... into all directories containing Go files
+func expandDirs(args []string) ([]string, error) {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	var dirs []string
+	for _, arg := range args {
+		if !strings.HasSuffix(arg, "...") {
+			dirs = append(dirs, arg)
+			continue
+		}
+
+		root := filepath.Clean(strings.TrimSuffix(strings.TrimSuffix(arg, "..."), "/"))
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				return nil
+			}
+			name := info.Name()
+			if path != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			if files, _ := filepath.Glob(filepath.Join(path, "*.go")); len(files) > 0 {
+				dirs = append(dirs, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dirs, nil
+}
+
+// lintDir checks tags of struct fields of packages in a directory
+func (l *linter) lintDir(dir string) ([]problem, error) {
+	pkgs, err := parser.ParseDir(l.fset, dir, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(pkgs))
+	for name := range pkgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []problem
+	for _, name := range names {
+		problems = append(problems, l.lintPackage(pkgs[name])...)
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, b := problems[i].pos, problems[j].pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+
+	return problems, nil
+}
+
+// lintPackage checks tags of struct fields of a package.
+// Types are resolved when possible, type errors are ignored and such fields are checked without a type.
+func (l *linter) lintPackage(pkg *ast.Package) []problem {
+	files := make([]*ast.File, 0, len(pkg.Files))
+	for _, file := range pkg.Files {
+		files = append(files, file)
+	}
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+	}
+	config := types.Config{
+		Importer: l.importer,
+		Error:    func(err error) {},
+	}
+	config.Check(pkg.Name, l.fset, files, info)
+
+	var problems []problem
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			if structType, ok := node.(*ast.StructType); ok {
+				parent := l.parentType(structType, info)
+				for _, field := range structType.Fields.List {
+					problems = append(problems, l.lintField(field, info, parent)...)
+				}
+			}
+			return true
+		})
+	}
+
+	return problems
+}
+
+// parentType converts a struct into a reflect type used to find fields referenced by validators.
+// It returns nil if a struct could not be converted, so referenced fields are not checked.
+func (l *linter) parentType(structType *ast.StructType, info *types.Info) reflect.Type {
+	typ, ok := info.TypeOf(structType).(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	depth := 0
+	for _, field := range structType.Fields.List {
+		validators, _ := l.validators(field)
+		for _, path := range regexpFieldPath.FindAllString(validators, -1) {
+			if n := strings.Count(path, "."); n > depth {
+				depth = n
+			}
+		}
+	}
+
+	return reflecttype.StructOf(typ, depth)
+}
+
+// regexpFieldPath matches dotted paths to fields of nested structs, e.g. Address.Zip
+var regexpFieldPath = regexp.MustCompile(`[\pL_][\pL\pN_]*(\.[\pL_][\pL\pN_]*)+`)
+
+// validators gets validators of a tag of a struct field
+func (l *linter) validators(field *ast.Field) (string, bool) {
+	if field.Tag == nil {
+		return "", false
+	}
+
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return "", false
+	}
+
+	return reflect.StructTag(tag).Lookup(l.tag)
+}
+
+// lintField checks a tag of a struct field, fields referenced by validators are found in a parent type if it is known
+func (l *linter) lintField(field *ast.Field, info *types.Info, parent reflect.Type) []problem {
+	validators, ok := l.validators(field)
+	if !ok {
+		return nil
+	}
+
+	typ := reflecttype.Of(info.TypeOf(field.Type))
+	err := l.validator.CheckFieldTag(typ, validators, parent)
+	if err == nil {
+		return nil
+	}
+
+	name := fieldName(field)
+	pos := l.fset.Position(field.Tag.Pos())
+
+	var problems []problem
+	if errs, ok := err.(validate.Errors); ok {
+		for _, err := range errs {
+			problems = append(problems, problem{pos, name, err})
+		}
+	} else {
+		problems = append(problems, problem{pos, name, err})
+	}
+
+	return problems
+}
+
+// fieldName gets a name of a struct field
+func fieldName(field *ast.Field) string {
+	if len(field.Names) == 0 {
+		expr := field.Type
+		if star, ok := expr.(*ast.StarExpr); ok {
+			expr = star.X
+		}
+		if selector, ok := expr.(*ast.SelectorExpr); ok {
+			return selector.Sel.Name
+		}
+		if ident, ok := expr.(*ast.Ident); ok {
+			return ident.Name
+		}
+		return "?"
+	}
+
+	names := make([]string, len(field.Names))
+	for i, name := range field.Names {
+		names[i] = name.Name
+	}
+
+	return strings.Join(names, ", ")
+}
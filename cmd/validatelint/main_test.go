@@ -0,0 +1,101 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLintDir(t *testing.T) {
+	l, err := newLinter("validate", []string{"secret"}, []string{"slug"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := l.lintDir("testdata/lint")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		file  string
+		line  int
+		field string
+	}{
+		{"fields.go", 21, "A"},
+		{"fields.go", 22, "B"},
+		{"fields.go", 23, "C"},
+		{"fields.go", 24, "D"},
+		{"lint.go", 8, "Retries"},
+		{"lint.go", 9, "Email"},
+		{"lint.go", 12, "Port"},
+		{"lint.go", 14, "Nested"},
+		{"lint.go", 15, "Unknown"},
+		{"lint.go", 19, "Values"},
+		{"types.go", 11, "Rate"},
+		{"types.go", 25, "Options"},
+	}
+
+	if len(problems) != len(expected) {
+		t.Fatalf("expected %d problems, got %v", len(expected), problems)
+	}
+
+	for i, p := range problems {
+		if filepath.Base(p.pos.Filename) != expected[i].file || p.pos.Line != expected[i].line || p.field != expected[i].field {
+			t.Errorf("expected problem at %v:%d in field %v, got %v", expected[i].file, expected[i].line, expected[i].field, p)
+		}
+		if !strings.HasPrefix(p.String(), p.pos.String()+": field "+p.field+": ") {
+			t.Errorf("unexpected format of a problem %v", p)
+		}
+	}
+}
+
+func TestLintText(t *testing.T) {
+	l, err := newLinter("validate", nil, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := l.lintDir("testdata/text")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(problems) != 2 || problems[0].field != "IP" || problems[1].field != "Level" {
+		t.Errorf("expected problems in fields IP and Level without text marshaling, got %v", problems)
+	}
+
+	l, err = newLinter("validate", nil, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err = l.lintDir("testdata/text")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(problems) != 0 {
+		t.Errorf("expected no problems with text marshaling, got %v", problems)
+	}
+}
+
+func TestNewLinter(t *testing.T) {
+	if _, err := newLinter("validate", []string{"bad name"}, nil, false); err == nil {
+		t.Errorf("expected an error for an invalid validator type")
+	}
+
+	if _, err := newLinter("validate", nil, []string{"bad name"}, false); err == nil {
+		t.Errorf("expected an error for an invalid format type")
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	if items := splitList(" a, ,b "); len(items) != 2 || items[0] != "a" || items[1] != "b" {
+		t.Errorf("unexpected items %v", items)
+	}
+
+	if items := splitList(""); len(items) != 0 {
+		t.Errorf("unexpected items %v", items)
+	}
+}
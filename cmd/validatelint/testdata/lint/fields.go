@@ -0,0 +1,25 @@
+package lint
+
+type Base struct {
+	ID string
+}
+
+type Limits struct {
+	Max int
+}
+
+type Range struct {
+	Base
+	Min    int
+	Max    int `validate:"gte_field=Min"`
+	Limits *Limits
+	Kind   string
+	Extra  string `validate:"required_if=Kind:x & lte_field=Limits.Max"`
+	Ref    string `validate:"ne_field=ID"`
+	start  int
+	end    int    `validate:"gt_field=start"`
+	A      int    `validate:"gt_field=Missing"`
+	B      string `validate:"required_if=Nope:x"`
+	C      int    `validate:"if=Zzz:1 ? gte=1"`
+	D      int    `validate:"lte_field=Limits.Min"`
+}
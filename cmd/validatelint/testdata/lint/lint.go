@@ -0,0 +1,20 @@
+package lint
+
+import "time"
+
+type Config struct {
+	Name    string            `validate:"empty=false"`
+	Timeout time.Duration     `validate:"gte=1s"`
+	Retries int               `validate:"gte=one"`
+	Email   string            `validate:"format=mail"`
+	Tags    []string          `validate:"empty=false > gte=1 & lte=10"`
+	Labels  map[string]string `validate:"empty=false [empty=false] > format=slug"`
+	Port    int               `validate:"between=1,100"`
+	Token   string            `validate:"secret=true"`
+	Nested  Nested            `validate:"nil=false"`
+	Unknown Missing           `validate:"emty=false"`
+}
+
+type Nested struct {
+	Values []int `validate:"> [empty=false]"`
+}
@@ -0,0 +1,26 @@
+package lint
+
+import (
+	"encoding/json"
+	"math/big"
+)
+
+type Payment struct {
+	Amount *big.Int    `validate:"nil=true | gte=0"`
+	Total  json.Number `validate:"gte=0.01 & lte=1e30"`
+	Rate   *big.Rat    `validate:"lt=x"`
+}
+
+type Money int64
+
+func (m Money) CompareTo(value string) (int, error) { return 0, nil }
+
+type Tags struct{ items []string }
+
+func (t *Tags) Len() int { return len(t.items) }
+
+type Order struct {
+	Price   Money `validate:"gte=1.00 & ne=0.50 & one_of=100,250"`
+	Tags    Tags  `validate:"lte=10"`
+	Options *Tags `validate:"nil=true | lte=x"`
+}
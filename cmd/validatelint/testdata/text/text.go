@@ -0,0 +1,17 @@
+package text
+
+import "net"
+
+type Level int
+
+func (l Level) String() string {
+	if l == 0 {
+		return "debug"
+	}
+	return "info"
+}
+
+type Host struct {
+	IP    net.IP `validate:"format=ip"`
+	Level Level  `validate:"one_of=debug,info & gte=0"`
+}
@@ -0,0 +1,140 @@
+package validate
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// Comparable is an interface for a type compared by comparison validators, e.g. Money or Version.
+// It is used instead of comparing a numeric value or a count of elements.
+type Comparable interface {
+
+	// CompareTo compares a value with a validator value, e.g. "1.2.0" for `validate:"gte=1.2.0"`, quotes are removed.
+	// It returns a negative number, zero, or a positive number if the value is less than, equal to,
+	// or greater than the validator value. Return ErrSyntax if the validator value could not be parsed,
+	// it is reported as ErrorSyntax, any other error is reported as ErrorValidation.
+	CompareTo(literal string) (int, error)
+}
+
+// Lengther is an interface for a type with a custom length compared by comparison validators,
+// e.g. a collection which is not a slice or a map. It is used instead of a count of elements.
+type Lengther interface {
+
+	// Len gets a length of a value.
+	Len() int
+}
+
+// comparableType and lengtherType are types of Comparable and Lengther interfaces
+var (
+	comparableType = reflect.TypeOf((*Comparable)(nil)).Elem()
+	lengtherType   = reflect.TypeOf((*Lengther)(nil)).Elem()
+)
+
+// implements checks if a type or a pointer to it implements an interface
+func implements(typ reflect.Type, iface reflect.Type) bool {
+	return typ.Implements(iface) || typ.Kind() != reflect.Ptr && reflect.PtrTo(typ).Implements(iface)
+}
+
+// interfaceOf gets a value implementing an interface, a value is copied if a pointer to it implements an interface.
+// It returns false if a value is a nil pointer or a nil interface.
+// It returns an error if a value is obtained from an unexported field, since its methods could not be called.
+func interfaceOf(value reflect.Value, iface reflect.Type, validatorType ValidatorType, validator string) (interface{}, bool, ErrorField) {
+	if !value.CanInterface() {
+		return nil, false, ErrorSyntax{
+			expression: validator,
+			near:       string(validatorType),
+			comment:    "could not call a method of an unexported field",
+		}
+	}
+
+	if (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && value.IsNil() {
+		return nil, false, nil
+	}
+
+	if value.Type().Implements(iface) {
+		return value.Interface(), true, nil
+	}
+
+	valueCopyPointer := reflect.New(value.Type())
+	valueCopyPointer.Elem().Set(value)
+
+	return valueCopyPointer.Interface(), true, nil
+}
+
+// validateCompareComparable compiles a comparison validator of a type implementing Comparable, a nil pointer is not valid
+func validateCompareComparable(validatorType ValidatorType, op compareOp, validator string) (checkFunc, ErrorField) {
+	return func(value reflect.Value, parent reflect.Value) ErrorField {
+		comparable, ok, errorField := interfaceOf(value, comparableType, validatorType, validator)
+		if errorField != nil {
+			return errorField
+		}
+
+		var cmp int
+		var err error
+		if ok {
+			cmp, err = comparable.(Comparable).CompareTo(validator)
+		}
+
+		switch {
+		case err == ErrSyntax:
+			return ErrorSyntax{
+				expression: validator,
+				near:       string(validatorType),
+				comment:    err.Error(),
+			}
+		case err != nil || !ok || !op.compareInt(int64(cmp), 0):
+			return ErrorValidation{
+				fieldValue:     value,
+				validatorType:  validatorType,
+				validatorValue: validator,
+				err:            err,
+			}
+		}
+		return nil
+	}, nil
+}
+
+// validateCompareLengther compiles a comparison validator of a type implementing Lengther, a nil pointer is not valid.
+// A value of an unexported field having a length of its own, e.g. a slice implementing sort.Interface,
+// is compared by a count of its elements, since Len could not be called.
+func validateCompareLengther(validatorType ValidatorType, op compareOp, typ reflect.Type, validator string, unit StringLength) (checkFunc, ErrorField) {
+	token, err := strconv.Atoi(validator)
+	if err != nil {
+		return nil, ErrorSyntax{
+			expression: validator,
+			near:       string(validatorType),
+			comment:    "could not parse or run",
+		}
+	}
+
+	hasLength := false
+	switch typ.Kind() {
+	case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
+		hasLength = true
+	}
+
+	return func(value reflect.Value, parent reflect.Value) ErrorField {
+		var length int
+		ok := true
+		if hasLength && !value.CanInterface() {
+			length = unit.len(value)
+		} else {
+			lengther, isLengther, errorField := interfaceOf(value, lengtherType, validatorType, validator)
+			if errorField != nil {
+				return errorField
+			}
+			if ok = isLengther; ok {
+				length = lengther.(Lengther).Len()
+			}
+		}
+
+		if !ok || !op.compareInt(int64(length), int64(token)) {
+			return ErrorValidation{
+				fieldValue:     value,
+				validatorType:  validatorType,
+				validatorValue: validator,
+			}
+		}
+		return nil
+	}, nil
+}
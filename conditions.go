@@ -0,0 +1,345 @@
+package validate
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// conditionFunc checks if a condition on fields of a parent struct holds
+type conditionFunc func(parent reflect.Value) (bool, ErrorField)
+
+// conditionCompiler compiles a condition for a type of a parent struct.
+// Near is a validator or a condition type used in syntax errors.
+type conditionCompiler func(condition string, near string, parent reflect.Type) (conditionFunc, ErrorField)
+
+// conditions are conditions which could be used in guards
+var conditions = map[string]conditionCompiler{
+	"if":      compileIf,
+	"unless":  compileUnless,
+	"with":    compileWith,
+	"without": compileWithout,
+}
+
+func validateRequiredIf(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return validateConditional(ValidatorRequiredIf, compileIf, true, validator, parent)
+}
+
+func validateRequiredUnless(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return validateConditional(ValidatorRequiredUnless, compileUnless, true, validator, parent)
+}
+
+func validateRequiredWith(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return validateConditional(ValidatorRequiredWith, compileWith, true, validator, parent)
+}
+
+func validateRequiredWithout(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return validateConditional(ValidatorRequiredWithout, compileWithout, true, validator, parent)
+}
+
+func validateExcludedIf(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return validateConditional(ValidatorExcludedIf, compileIf, false, validator, parent)
+}
+
+// validateConditional compiles a validator checking if a value is set or not set when a condition holds
+func validateConditional(validatorType ValidatorType, compile conditionCompiler, required bool, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	condition, err := compile(validator, string(validatorType), parent)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(value reflect.Value, parent reflect.Value) ErrorField {
+		holds, err := condition(parent)
+		if err != nil {
+			return err
+		}
+		if holds && isZero(value) == required {
+			return ErrorValidation{
+				fieldValue:     value,
+				validatorType:  validatorType,
+				validatorValue: validator,
+			}
+		}
+		return nil
+	}, nil
+}
+
+// compileIf compiles a condition which holds if a field equals any of the given values, e.g. PaymentMethod:card,paypal
+func compileIf(condition string, near string, parent reflect.Type) (conditionFunc, ErrorField) {
+	i := strings.IndexByte(condition, ':')
+	if i < 0 || !regexpFieldPath.MatchString(strings.TrimSpace(condition[:i])) || len(parseTokens(condition[i+1:])) == 0 {
+		return nil, ErrorSyntax{
+			expression: condition,
+			near:       near,
+			comment:    "could not parse",
+		}
+	}
+
+	if parent == nil {
+		return lazyCondition(compileIf, condition, near), nil
+	}
+
+	index, fieldType, ok := findField(parent, strings.TrimSpace(condition[:i]))
+	if !ok {
+		return nil, ErrorSyntax{
+			expression: condition,
+			near:       near,
+			comment:    "could not find field",
+		}
+	}
+
+	match := compileMatch(indirectType(fieldType), condition[i+1:])
+	if match == nil {
+		return nil, ErrorSyntax{
+			expression: condition,
+			near:       near,
+			comment:    "could not compare",
+		}
+	}
+
+	return func(parent reflect.Value) (bool, ErrorField) {
+		field, ok := fieldByIndex(parent, index)
+		if ok {
+			field, ok = indirect(field)
+		}
+		return ok && match(field), nil
+	}, nil
+}
+
+// compileUnless compiles a condition which holds if a field does not equal any of the given values
+func compileUnless(condition string, near string, parent reflect.Type) (conditionFunc, ErrorField) {
+	conditionIf, err := compileIf(condition, near, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(parent reflect.Value) (bool, ErrorField) {
+		holds, err := conditionIf(parent)
+		return !holds, err
+	}, nil
+}
+
+// compileWith compiles a condition which holds if any of the given fields are set
+func compileWith(condition string, near string, parent reflect.Type) (conditionFunc, ErrorField) {
+	return compilePresence(compileWith, true, condition, near, parent)
+}
+
+// compileWithout compiles a condition which holds if any of the given fields are not set
+func compileWithout(condition string, near string, parent reflect.Type) (conditionFunc, ErrorField) {
+	return compilePresence(compileWithout, false, condition, near, parent)
+}
+
+// compilePresence compiles a condition which holds if any of the given fields are set or not set
+func compilePresence(compile conditionCompiler, set bool, condition string, near string, parent reflect.Type) (conditionFunc, ErrorField) {
+	paths := parseTokens(condition)
+	for _, path := range paths {
+		if !regexpFieldPath.MatchString(path.(string)) {
+			paths = nil
+			break
+		}
+	}
+	if len(paths) == 0 {
+		return nil, ErrorSyntax{
+			expression: condition,
+			near:       near,
+			comment:    "could not parse",
+		}
+	}
+
+	if parent == nil {
+		return lazyCondition(compile, condition, near), nil
+	}
+
+	indexes := make([][][]int, len(paths))
+	for i, path := range paths {
+		var ok bool
+		if indexes[i], _, ok = findField(parent, path.(string)); !ok {
+			return nil, ErrorSyntax{
+				expression: condition,
+				near:       near,
+				comment:    "could not find field",
+			}
+		}
+	}
+
+	return func(parent reflect.Value) (bool, ErrorField) {
+		for _, index := range indexes {
+			field, ok := fieldByIndex(parent, index)
+			if (ok && !isZero(field)) == set {
+				return true, nil
+			}
+		}
+		return false, nil
+	}, nil
+}
+
+// lazyCondition compiles a condition when a type of a parent struct is known
+func lazyCondition(compile conditionCompiler, condition string, near string) conditionFunc {
+	return func(parent reflect.Value) (bool, ErrorField) {
+		if !parent.IsValid() {
+			return false, ErrorSyntax{
+				expression: condition,
+				near:       near,
+				comment:    "could not find field",
+			}
+		}
+		conditionFunc, err := compile(condition, near, parent.Type())
+		if err != nil {
+			return false, err
+		}
+		return conditionFunc(parent)
+	}
+}
+
+// compileMatch compiles a func checking if a value equals any of the given values or returns nil if values could not be parsed
+func compileMatch(typ reflect.Type, values string) func(value reflect.Value) bool {
+	if typ.Kind() == reflect.Bool {
+		tokens := parseTokens(values)
+		for i, token := range tokens {
+			var err error
+			if tokens[i], err = strconv.ParseBool(token.(string)); err != nil {
+				return nil
+			}
+		}
+		return func(value reflect.Value) bool {
+			return tokenOneOf(value.Bool(), tokens)
+		}
+	}
+
+	check, err := validateOneOf(typ, values, nil)
+	if err != nil {
+		return nil
+	}
+
+	return func(value reflect.Value) bool {
+		return check(value, reflect.Value{}) == nil
+	}
+}
+
+// compileGuard compiles conditions of a guard into a single condition
+func compileGuard(guard string, parent reflect.Type) (conditionFunc, ErrorField) {
+	n, err := parseGuard(guard)
+	if err != nil {
+		return nil, err
+	}
+
+	return compileConditions(*n, parent)
+}
+
+// compileConditions compiles a condition or conditions combined by & or | operator,
+// conditions are checked in order until the result is known
+func compileConditions(n node, parent reflect.Type) (conditionFunc, ErrorField) {
+	if n.op == 0 {
+		condition, err := conditions[string(n.validator.Type)](n.validator.Value, string(n.validator.Type), parent)
+		if err != nil {
+			return nil, err
+		}
+		if n.validator.Negated {
+			condition = negateCondition(condition)
+		}
+		return condition, nil
+	}
+
+	operands := make([]conditionFunc, len(n.operands))
+	for i, operand := range n.operands {
+		var err ErrorField
+		if operands[i], err = compileConditions(operand, parent); err != nil {
+			return nil, err
+		}
+	}
+
+	// Result of | operator is known once a condition holds, result of & operator is known once it does not hold
+	known := n.op == '|'
+	return func(parent reflect.Value) (bool, ErrorField) {
+		for _, operand := range operands {
+			holds, err := operand(parent)
+			if err != nil {
+				return false, err
+			}
+			if holds == known {
+				return known, nil
+			}
+		}
+		return !known, nil
+	}, nil
+}
+
+// negateCondition negates a condition
+func negateCondition(condition conditionFunc) conditionFunc {
+	return func(parent reflect.Value) (bool, ErrorField) {
+		holds, err := condition(parent)
+		return !holds, err
+	}
+}
+
+// parseGuard parses conditions of a guard and checks that conditions exist
+func parseGuard(guard string) (*node, ErrorField) {
+	n, err := parseValidators(guard)
+	if err != nil {
+		return nil, err
+	}
+
+	if n == nil {
+		return nil, ErrorSyntax{
+			expression: "?",
+			near:       guard,
+			comment:    "expected a condition",
+		}
+	}
+
+	for _, condition := range n.validators() {
+		if _, ok := conditions[string(condition.Type)]; !ok {
+			return nil, ErrorSyntax{
+				expression: string(condition.Type),
+				near:       guard,
+				comment:    "could not find a condition",
+			}
+		}
+	}
+
+	return n, nil
+}
+
+// splitGuard splits value validators into a guard and validators applied when a guard holds
+func splitGuard(validators string) (guard string, expression string, ok bool) {
+	i := indexUnquoted(validators, "?")
+	if i < 0 {
+		return "", validators, false
+	}
+
+	return strings.TrimSpace(validators[:i]), strings.TrimSpace(validators[i+1:]), true
+}
+
+// isZero checks if a value is not set, i.e. it is nil, empty, or a zero value
+func isZero(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Chan, reflect.Func:
+		return value.IsNil()
+	case reflect.String, reflect.Map, reflect.Slice:
+		return value.Len() == 0
+	case reflect.Bool:
+		return !value.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return value.Float() == 0
+	case reflect.Complex64, reflect.Complex128:
+		return value.Complex() == 0
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if !isZero(value.Index(i)) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if !isZero(value.Field(i)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
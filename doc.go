@@ -8,9 +8,21 @@ For example, **validate** package is useful when unmarshalling YAML or JSON.
 
 This package supports most of the built-in types: int8, uint8, int16, uint16, int32,
 uint32, int64, uint64, int, uint, uintptr, float32, float64 and aliased types:
-time.Duration, byte (uint8), rune (int32).
-
-Following validators are available: gt, lt, gte, lte, empty, nil, one_of, format.
+time.Duration, byte (uint8), rune (int32). Comparison validators also support time.Time and *time.Time.
+Comparison and one_of validators compare json.Number, *big.Int, *big.Float, and *big.Rat exactly,
+limits may exceed 64 bits or be fractions, e.g. `validate:"gte=0.01 & lte=1e30"` or `validate:"lt=1/3"`.
+A nil pointer is not valid, a *big.Float is compared by its exact value, e.g. 0.1 of float64 precision is greater than 0.1.
+Numbers of unexported fields other than json.Number could not be read, an ErrorSyntax is reported instead.
+
+Following validators are available: eq, ne, gt, lt, gte, lte, empty, nil, one_of, format, regexp,
+is, is_not, before, after, contains, excludes, prefix, suffix, contains_any, excludes_all,
+is_fold, is_not_fold, contains_fold, excludes_fold, prefix_fold, suffix_fold, past, future,
+eq_field, ne_field, gt_field, lt_field, gte_field, lte_field, required_if, required_unless,
+required_with, required_without, excluded_if.
+
+Comparison validators compare a count of characters of a string, use string validators to compare its content,
+e.g. `validate:"is=v1"` or `validate:"before=m"`. Validators with the _fold suffix ignore case
+using Unicode case folding the same way strings.EqualFold does.
 
 Basic usage
 
@@ -42,6 +54,47 @@ It is possible to specify multiple validators using & (ampersand) or | (vertical
 		field int `validate:"gte=-20 & lte=-10 | gte=10 & lte=20"`
 	}
 
+Use parentheses to group validators.
+
+	type S struct {
+		// Check that the value is in the range of 1...5 or equal to 10, and it is not equal to 3
+		field int `validate:"(gte=1 & lte=5 | eq=10) & ne=3"`
+	}
+
+An error reports the validator which decided the result, i.e. the first failed operand of & operator,
+or the last operand of | operator if all of them failed. E.g. 7 fails the validator above with eq=10, and 3 fails it with ne=3.
+
+Use ! (exclamation mark) operator to negate a validator or an expression in parentheses.
+If a value passes a negated validator, validation fails with ErrorValidation, which Negated method returns true.
+
+	type S struct {
+		// Check that the value is neither root nor admin, and it is not an IP address
+		field string `validate:"!one_of=root,admin & !(format=ipv4 | format=ipv6)"`
+	}
+
+Syntax errors of unbalanced parentheses and misplaced operators report a column in the expression,
+use ErrorSyntax.Column to get it.
+
+Quoted values
+
+Use single or double quotes to specify values containing whitespace or characters used by operators,
+e.g. commas, equal signs, brackets, or vertical bars. A backslash escapes the next character in a quoted value.
+Quotes are removed before a value is passed to a validator, including custom validators,
+tokens of one_of and conditional validators are unquoted one by one. Errors report a value as it is written in a tag.
+
+	type S struct {
+		// Check that the value is either "a,b", "x=y", or " c"
+		field string `validate:"one_of='a,b','x=y',' c'"`
+	}
+
+Quote a regular expression of the regexp validator, it is compiled once when a type is validated first,
+an invalid regular expression is reported as ErrorSyntax.
+
+	type S struct {
+		// Check that each slug consists of lowercase words separated by dashes
+		slugs []string `validate:"> regexp='^[a-z]+(-[a-z]+)*$'"`
+	}
+
 Slice and array validation
 
 You can use a regular syntax to validate a slice/array. To validate slice/array values, specify validators after an arrow character.
@@ -118,6 +171,98 @@ You can use brackets and arrow syntax to deep to as many levels as you need.
 	// nil=false will be applied to the map value
 	// gte=0 & lte=10 will be applied to the A.field
 
+Cross-field validation
+
+You can compare a value with a value of another field of the same struct using eq_field, ne_field,
+gt_field, lt_field, gte_field, and lte_field validators. Use a dot to refer to a field of a nested struct.
+Numbers and times are compared by value, strings are compared by content for equality, and a count of elements is compared otherwise.
+Pointers are dereferenced on both sides, a nil pointer is not valid.
+
+	type S struct {
+		Password        string
+		PasswordConfirm string        `validate:"eq_field=Password"`
+		Start           time.Duration
+		End             time.Duration `validate:"gt_field=Start"`
+		Count           int           `validate:"lte_field=Limits.Max"`
+		Limits          Limits
+	}
+
+Comparable types
+
+Comparison validators call CompareTo of types implementing validate.Comparable, e.g. Money or Version,
+and Len of types implementing validate.Lengther instead of comparing a numeric value or a count of elements.
+A validator value is passed to CompareTo without quotes, return validate.ErrSyntax if it could not be parsed.
+Methods are not called for values of unexported fields, an ErrorSyntax is reported instead.
+Strings, slices, arrays, and maps of unexported fields are compared by their length, e.g. a slice implementing sort.Interface.
+
+	type Version string
+
+	func (v Version) CompareTo(literal string) (int, error) {
+		...
+	}
+
+	type S struct {
+		Version Version `validate:"gte=1.2.0 & lt=2.0.0"`
+	}
+
+Text values
+
+Use validate.SetTextMarshaling(true) or validate.WithTextMarshaling to validate values implementing encoding.TextMarshaler
+or fmt.Stringer, e.g. net.IP, url.URL, or enum types, as strings. MarshalText is preferred to String.
+Text is used by empty, one_of, format, regexp, string validators, and comparison validators except for numbers,
+which are still compared by value. Strings, time.Duration, time.Time, numbers of arbitrary precision,
+and comparable types are validated as they are. Errors report the original value,
+a nil pointer and a value which could not be marshaled are not valid.
+
+	type S struct {
+		IP    net.IP `validate:"format=ip"`
+		Level Level  `validate:"one_of=info,error"` // Level is an int implementing fmt.Stringer
+	}
+
+Time validation
+
+Comparison validators compare time.Time and *time.Time values with a time in RFC 3339 format, a date,
+or a time relative to the current time: now, now-24h, or now+30d. Use past and future validators to check
+if a time is in the past or in the future. A nil pointer is not valid.
+Times of unexported fields could not be read, an ErrorSyntax is reported instead.
+
+	type S struct {
+		Birthday time.Time  `validate:"past=true & gte=1900-01-01"`
+		Expires  *time.Time `validate:"nil=true | gt=now+30d"`
+		Created  time.Time  `validate:"gte=2020-01-01T00:00:00Z & lte=now"`
+	}
+
+The current time is got using time.Now. Use validate.SetClock or validate.WithClock to make tests deterministic.
+
+	validate.SetClock(func() time.Time {
+		return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	})
+
+Conditional validation
+
+Use required_if, required_unless, required_with, required_without, and excluded_if validators
+to check if a value is set depending on other fields of the same struct.
+A value is set if it is not nil, not empty, and not a zero value.
+
+	type S struct {
+		PaymentMethod string
+		// Required when PaymentMethod is card or debit
+		CardNumber string `validate:"required_if=PaymentMethod:card,debit"`
+		// Required when Email is not set
+		Phone string `validate:"required_without=Email"`
+		Email string
+	}
+
+To perform validators only when a condition holds, specify conditions before a question mark.
+Conditions are if=Field:values, unless=Field:values, with=Fields, and without=Fields,
+they could be combined using & and | operators. A question mark has the lowest priority.
+
+	type S struct {
+		PaymentMethod string
+		// Check that the slice contains one or two elements when PaymentMethod is card
+		Cards []string `validate:"if=PaymentMethod:card ? gte=1 & lte=2"`
+	}
+
 Custom validation
 
 You can specify custom validation method.
@@ -134,6 +279,104 @@ Custom validation also works for a substuct, if a substruct is defined in an exp
 		return nil
 	}
 
+Implement ValidateContext instead of Validate to use request-scoped data, e.g. a tenant, a locale, or a database handle.
+Use validate.ValidateContext or validate.ValidateAllContext to pass a context, otherwise context.Background() is used.
+If a context is done, validation stops between elements of maps, slices, and arrays, and the error of the context is returned.
+
+	func (u User) ValidateContext(ctx context.Context) error {
+		return checkTenant(ctx, u.TenantID)
+	}
+
+	err := validate.ValidateContext(ctx, user)
+
+Custom validators
+
+You can register your own validator type to use it in tags.
+Return validate.ErrSyntax if the validator could not be run for a value, any other error means that a value is not valid.
+
+	validate.RegisterValidator("even", func(value reflect.Value, validator string) error {
+		if value.Kind() != reflect.Int {
+			return validate.ErrSyntax
+		}
+		if value.Int()%2 != 0 {
+			return errors.New("value should be even")
+		}
+		return nil
+	})
+
+	type S struct {
+		field []int `validate:"> even"`
+	}
+
+Custom formats
+
+You can register your own format to use it with the format validator.
+
+	validate.RegisterFormat("sku", func(value string) bool {
+		return strings.HasPrefix(value, "SKU-")
+	})
+
+	validate.RegisterFormatRegexp("tenant_slug", `^[a-z][a-z0-9-]{2,31}$`)
+
+	type S struct {
+		sku  string `validate:"format=sku"`
+		slug string `validate:"format=tenant_slug"`
+	}
+
+Validator instances
+
+Package level functions use a default configuration shared across the program.
+Use validate.New to create a Validator with its own tag name, validators, formats, field name function, error mode,
+unit of a string length, clock, and file system.
+
+	v := validate.New(
+		validate.WithTag("check"),
+		validate.WithFieldNameFunc(validate.TagFieldName("json")),
+		validate.WithAllErrors(),
+	)
+
+	v.RegisterFormat("sku", isSKU)
+
+	err := v.Validate(element)
+
+Tags are parsed once per type and cached by a Validator, so validating values of the same type again
+does not parse tags. Registering validators or formats and changing the configuration drops the cache.
+
+Clock and file system
+
+Validation results of time validators, file and dir formats depend on the host: the current time and files.
+Use validate.WithClock and validate.WithFileSystem, or validate.SetClock and validate.SetFileSystem for
+package level functions, to validate values against a fixed time and an in-memory file system, e.g. in tests.
+Any fs.StatFS, such as fstest.MapFS, could be used as a file system.
+
+	v := validate.New(
+		validate.WithClock(func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }),
+		validate.WithFileSystem(fstest.MapFS{"config/app.yaml": &fstest.MapFile{}}),
+	)
+
+Tag name
+
+Validators are specified in the validate tag by default. If the validate tag is already used by another package,
+e.g. during migration from another validation package, change the tag name for package level functions
+using validate.SetTag or for a Validator using validate.WithTag.
+
+	validate.SetTag("vd")
+
+	type S struct {
+		field string `validate:"required" vd:"empty=false"`
+	}
+
+String length
+
+Comparison validators count bytes of a string by default, so a string of 6 Cyrillic letters has a length of 12.
+Use validate.SetStringLength or validate.WithStringLength to count runes or user-perceived characters instead.
+
+	validate.SetStringLength(validate.StringLengthRunes)
+
+	type S struct {
+		name string `validate:"gte=3 & lte=25 & format=alnum_unicode"` // Should be between 3 and 25 letters
+	}
+
 Handling errors
 
 Validate method returns two types of errors: ErrorSyntax and ErrorValidation.
@@ -155,5 +398,62 @@ You can handle an error type using switch syntax.
 			// Handle other errors
 		}
 	}
+
+Both error types implement validate.ErrorField interface. FieldName returns a name of a struct field,
+while FieldPath returns a full path to the value including nested structs, slice and array indices,
+map keys, and dereferenced pointers. The path is rendered as Users[3].Addresses[home].Zip.
+
+	if e, ok := err.(validate.ErrorField); ok {
+		fmt.Println(e.FieldPath())
+	}
+
+By default Go field names are used in errors. Use validate.SetFieldNameFunc to change it,
+e.g. to use names from json tags. Embedded structs are inlined as encoding/json does, so paths match the wire format.
+
+	validate.SetFieldNameFunc(validate.TagFieldName("json"))
+
+Checking tags
+
+Syntax errors are found only when a value is validated, so an error in a tag of an element of an empty slice
+or a nil pointer may stay unnoticed. Use validate.CheckType or validate.MustCompile to check tags of a type
+and all types it refers to without a value.
+
+	func init() {
+		validate.MustCompile(S{})
+	}
+
+Use validate.CheckTag to check a single tag, a type may be nil to check only syntax, validator types, and formats.
+Use validate.CheckFieldTag to check a tag of a field together with fields of its struct referenced by the tag.
+The validatelint command uses it to check tags across a codebase without running a program.
+
+	go run gopkg.in/dealancer/validate.v2/cmd/validatelint ./...
+
+Collecting all errors
+
+Validate stops at the first error. Use validate.ValidateAll to walk the whole struct tree
+and collect every error. It returns validate.Errors, which is a list of errors
+ordered by struct fields, slice and array indices, and sorted map keys.
+
+	type S struct {
+		a int    `validate:"gte=0"`
+		b string `validate:"empty=false"`
+	}
+
+	if err := validate.ValidateAll(S{-1, ""}); err != nil {
+		for _, e := range err.(validate.Errors) {
+			// Handle each error
+		}
+	}
+
+Generated code
+
+The validategen command generates Validate methods which validate struct types without reflection
+and return the same errors validate.Validate does. Values which could not be validated without reflection,
+e.g. interfaces or values using custom validators, are validated using reflection.
+
+	//go:generate go run gopkg.in/dealancer/validate.v2/cmd/validategen -type=User
+
+Generated types implement validate.GeneratedValidator. Use validate.CrossCheck in tests to compare
+results of generated code and validation using reflection, or run validategen with -crosscheck.
 */
 package validate
@@ -1,27 +1,37 @@
 package validate
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 )
 
+// ErrSyntax is returned by a custom validator function
+// if a validator value could not be parsed or a validator could not be run for a value.
+var ErrSyntax = errors.New("could not parse or run")
+
 // ErrorField is an error interface for field/value error.
 type ErrorField interface {
 	error
 	FieldName() string
+	FieldPath() Path
 }
 
 // errorField is a setter interface
 type errorField interface {
-	setFieldName(string)
+	setFieldPath(Path)
 }
 
 // ErrorValidation occurs when validator does not validate.
 type ErrorValidation struct {
 	fieldName      string
+	fieldPath      Path
 	fieldValue     reflect.Value
 	validatorType  ValidatorType
 	validatorValue string
+	negated        bool
+	err            error
 }
 
 // FieldName gets a field name.
@@ -29,9 +39,20 @@ func (e ErrorValidation) FieldName() string {
 	return e.fieldName
 }
 
-// setFieldName sets a field name.
-func (e *ErrorValidation) setFieldName(fieldName string) {
-	e.fieldName = fieldName
+// FieldPath gets a path to a field.
+func (e ErrorValidation) FieldPath() Path {
+	return e.fieldPath
+}
+
+// Negated reports whether a negated validator failed, i.e. a value passed a validator negated using ! operator.
+func (e ErrorValidation) Negated() bool {
+	return e.negated
+}
+
+// setFieldPath sets a path to a field and a field name.
+func (e *ErrorValidation) setFieldPath(fieldPath Path) {
+	e.fieldName = fieldPath.fieldName()
+	e.fieldPath = fieldPath
 }
 
 // Error returns an error.
@@ -40,19 +61,36 @@ func (e ErrorValidation) Error() string {
 	if len(e.validatorValue) > 0 {
 		validator += "=" + e.validatorValue
 	}
+	if e.negated {
+		validator = "!" + validator
+	}
 
-	if len(e.fieldName) > 0 {
-		return fmt.Sprintf("Validation error in field \"%v\" of type \"%v\" using validator \"%v\"", e.fieldName, e.fieldValue.Type(), validator)
+	var message string
+	if fieldPath := e.fieldPath.String(); len(fieldPath) > 0 {
+		message = fmt.Sprintf("Validation error in field \"%v\" of type \"%v\" using validator \"%v\"", fieldPath, e.fieldValue.Type(), validator)
+	} else {
+		message = fmt.Sprintf("Validation error in value of type \"%v\" using validator \"%v\"", e.fieldValue.Type(), validator)
 	}
 
-	return fmt.Sprintf("Validation error in value of type \"%v\" using validator \"%v\"", e.fieldValue.Type(), validator)
+	if e.err != nil {
+		message += ": " + e.err.Error()
+	}
+
+	return message
+}
+
+// Unwrap returns an error returned by a custom validator function.
+func (e ErrorValidation) Unwrap() error {
+	return e.err
 }
 
 // ErrorSyntax occurs when there is a syntax error.
 type ErrorSyntax struct {
 	fieldName  string
+	fieldPath  Path
 	expression string
 	near       string
+	column     int
 	comment    string
 }
 
@@ -61,32 +99,61 @@ func (e ErrorSyntax) FieldName() string {
 	return e.fieldName
 }
 
-// setFieldName sets a field name.
-func (e *ErrorSyntax) setFieldName(fieldName string) {
-	e.fieldName = fieldName
+// FieldPath gets a path to a field.
+func (e ErrorSyntax) FieldPath() Path {
+	return e.fieldPath
+}
+
+// Column gets a column of a syntax error in the expression, starting from 1, or 0 if it is not known.
+func (e ErrorSyntax) Column() int {
+	return e.column
+}
+
+// setFieldPath sets a path to a field and a field name.
+func (e *ErrorSyntax) setFieldPath(fieldPath Path) {
+	e.fieldName = fieldPath.fieldName()
+	e.fieldPath = fieldPath
 }
 
 // Error returns an error.
 func (e ErrorSyntax) Error() string {
-	if len(e.fieldName) > 0 {
-		return fmt.Sprintf("Syntax error when validating field \"%v\", expression \"%v\" near \"%v\": %v", e.fieldName, e.expression, e.near, e.comment)
+	near := fmt.Sprintf("near \"%v\"", e.near)
+	if e.column > 0 {
+		near += fmt.Sprintf(" at column %v", e.column)
+	}
+
+	if fieldPath := e.fieldPath.String(); len(fieldPath) > 0 {
+		return fmt.Sprintf("Syntax error when validating field \"%v\", expression \"%v\" %v: %v", fieldPath, e.expression, near, e.comment)
+	}
+
+	return fmt.Sprintf("Syntax error when validating value, expression \"%v\" %v: %v", e.expression, near, e.comment)
+}
+
+// Errors is a list of errors returned by ValidateAll.
+type Errors []error
+
+// Error returns an error.
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
 	}
 
-	return fmt.Sprintf("Syntax error when validating value, expression \"%v\" near \"%v\": %v", e.expression, e.near, e.comment)
+	return strings.Join(messages, "; ")
 }
 
-// Set field name
-func setFieldName(err ErrorField, fieldName string) ErrorField {
+// Set a path to a field
+func setFieldPath(err ErrorField, fieldPath Path) ErrorField {
 	switch (err).(type) {
 	case ErrorValidation:
 		e := err.(ErrorValidation)
 		var i interface{} = &e
-		(i).(errorField).setFieldName(fieldName)
+		(i).(errorField).setFieldPath(fieldPath)
 		return e
 	case ErrorSyntax:
 		e := err.(ErrorSyntax)
 		var i interface{} = &e
-		(i).(errorField).setFieldName(fieldName)
+		(i).(errorField).setFieldPath(fieldPath)
 		return e
 	}
 
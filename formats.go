@@ -3,9 +3,11 @@ package validate
 import (
 	"bytes"
 	"crypto/sha256"
+	"fmt"
 	"net"
 	"net/url"
 	"os"
+	"regexp"
 	"strings"
 
 	urn "github.com/leodido/go-urn"
@@ -67,10 +69,105 @@ const (
 	FormatPostcode             FormatType = "postcode"
 )
 
+// FormatFunc is a custom format validator function.
+// It returns true if a string is in the format.
+type FormatFunc func(value string) bool
+
 // formatFunc is an interface for format validator func
 type formatFunc func(value string) bool
 
-func getFormatTypeMap() map[FormatType]formatFunc {
+// FileSystem is a file system used by file and dir formats.
+// It is satisfied by fs.StatFS, e.g. fstest.MapFS, so formats could be checked against an in-memory file system.
+type FileSystem interface {
+
+	// Stat gets information about a named file.
+	Stat(name string) (os.FileInfo, error)
+}
+
+// osFileSystem is a file system of the operating system, it is used by default
+type osFileSystem struct{}
+
+// Stat gets information about a named file using os.Stat
+func (osFileSystem) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// regexpFormatType matches a valid format type
+var regexpFormatType = regexp.MustCompile(`^[[:alnum:]_]+$`)
+
+// RegisterFormat registers a custom format, which then can be used with the format validator.
+// It returns an error if a format type is not valid
+// or a format of the same type is built-in or already registered.
+// It is safe to call RegisterFormat concurrently.
+func (v *Validator) RegisterFormat(formatType FormatType, f FormatFunc) error {
+	return v.registerFormat(formatType, f, false)
+}
+
+// RegisterFormatRegexp registers a custom format the same way RegisterFormat does.
+// A string is in the format if it matches a regular expression.
+// It returns an error if a regular expression could not be compiled.
+func (v *Validator) RegisterFormatRegexp(formatType FormatType, pattern string) error {
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+
+	return v.registerFormat(formatType, regex.MatchString, false)
+}
+
+// OverrideFormat registers a custom format the same way RegisterFormat does,
+// but it replaces a built-in or already registered format of the same type.
+func (v *Validator) OverrideFormat(formatType FormatType, f FormatFunc) error {
+	return v.registerFormat(formatType, f, true)
+}
+
+// RegisterFormat registers a custom format used by package level functions.
+// See Validator.RegisterFormat for details.
+//
+//  validate.RegisterFormat("sku", func(value string) bool {
+//  	return strings.HasPrefix(value, "SKU-")
+//  })
+func RegisterFormat(formatType FormatType, f FormatFunc) error {
+	return defaultValidator.RegisterFormat(formatType, f)
+}
+
+// RegisterFormatRegexp registers a custom format used by package level functions.
+// See Validator.RegisterFormatRegexp for details.
+//
+//  validate.RegisterFormatRegexp("tenant_slug", `^[a-z][a-z0-9-]{2,31}$`)
+func RegisterFormatRegexp(formatType FormatType, pattern string) error {
+	return defaultValidator.RegisterFormatRegexp(formatType, pattern)
+}
+
+// OverrideFormat registers a custom format used by package level functions.
+// See Validator.OverrideFormat for details.
+func OverrideFormat(formatType FormatType, f FormatFunc) error {
+	return defaultValidator.OverrideFormat(formatType, f)
+}
+
+// registerFormat registers a custom format
+func (v *Validator) registerFormat(formatType FormatType, f FormatFunc, override bool) error {
+	if !regexpFormatType.MatchString(string(formatType)) {
+		return fmt.Errorf("format type \"%v\" is not valid", formatType)
+	}
+	if f == nil {
+		return fmt.Errorf("format \"%v\" is nil", formatType)
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if _, ok := v.formats[formatType]; ok && !override {
+		return fmt.Errorf("format \"%v\" is already registered", formatType)
+	}
+
+	v.formats[formatType] = formatFunc(f)
+	v.resetPlans()
+
+	return nil
+}
+
+func (v *Validator) getFormatTypeMap() map[FormatType]formatFunc {
 	return map[FormatType]formatFunc{
 		FormatAlpha:                formatAlpha,
 		FormatAlnum:                formatAlnum,
@@ -88,7 +185,7 @@ func getFormatTypeMap() map[FormatType]formatFunc {
 		FormatURL:                  formatURL,
 		FormatURI:                  formatURI,
 		FormatUrnRFC2141:           formatUrnRFC2141,
-		FormatFile:                 formatFile,
+		FormatFile:                 v.formatFile,
 		FormatBase64:               formatBase64,
 		FormatBase64URL:            formatBase64URL,
 		FormatISBN:                 formatISBN,
@@ -118,7 +215,7 @@ func getFormatTypeMap() map[FormatType]formatFunc {
 		FormatHostnameRFC1123:      formatHostnameRFC1123,
 		FormatFQDN:                 formatFQDN,
 		FormatURLEncoded:           formatURLEncoded,
-		FormatDir:                  formatDir,
+		FormatDir:                  v.formatDir,
 		FormatPostcode:             formatPostcode,
 	}
 }
@@ -483,8 +580,8 @@ func formatUrnRFC2141(value string) bool {
 }
 
 // formatFile is the validation function for validating if the current field's value is a valid file path.
-func formatFile(value string) bool {
-	fileInfo, err := os.Stat(value)
+func (v *Validator) formatFile(value string) bool {
+	fileInfo, err := v.getFileSystem().Stat(value)
 	if err != nil {
 		return false
 	}
@@ -581,8 +678,8 @@ func formatFQDN(value string) bool {
 }
 
 // formatDir is the validation function for validating if the current field's value is a valid directory.
-func formatDir(value string) bool {
-	fileInfo, err := os.Stat(value)
+func (v *Validator) formatDir(value string) bool {
+	fileInfo, err := v.getFileSystem().Stat(value)
 	if err != nil {
 		return false
 	}
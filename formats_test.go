@@ -1,8 +1,10 @@
 package validate
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 type FormatTestGroup struct {
@@ -817,7 +819,7 @@ func getFormatTestGroups() []FormatTestGroup {
 
 func Test(t *testing.T) {
 	groups := getFormatTestGroups()
-	formatTypeMap := getFormatTypeMap()
+	formatTypeMap := New().getFormatTypeMap()
 
 	for _, group := range groups {
 		for _, test := range group.Tests {
@@ -827,3 +829,136 @@ func Test(t *testing.T) {
 		}
 	}
 }
+
+func TestRegisterFormat(t *testing.T) {
+	// Register formats on a fresh default validator to run the test repeatedly
+	defer func(v *Validator) { defaultValidator = v }(defaultValidator)
+	defaultValidator = New()
+
+	if nil != RegisterFormat("test_sku", func(value string) bool {
+		return len(value) > 4 && value[:4] == "SKU-"
+	}) {
+		t.Errorf("register format does not register a format")
+	}
+
+	if nil == RegisterFormat("test_sku", formatAlpha) {
+		t.Errorf("register format registers a format twice")
+	}
+
+	if nil == RegisterFormat(FormatEmail, formatAlpha) {
+		t.Errorf("register format overrides a built-in format")
+	}
+
+	if nil == RegisterFormat("test sku", formatAlpha) {
+		t.Errorf("register format registers an invalid format type")
+	}
+
+	if nil != RegisterFormatRegexp("test_slug", `^[a-z][a-z0-9-]{2,31}$`) {
+		t.Errorf("register format regexp does not register a format")
+	}
+
+	if nil == RegisterFormatRegexp("test_invalid", `^[a-z`) {
+		t.Errorf("register format regexp registers an invalid regular expression")
+	}
+
+	if nil != Validate(struct {
+		sku  string   `validate:"format=test_sku"`
+		slug []string `validate:"> format=test_slug"`
+	}{
+		sku:  "SKU-1",
+		slug: []string{"tenant-1"},
+	}) {
+		t.Errorf("custom format does not validate")
+	}
+
+	if nil == Validate(struct {
+		sku string `validate:"format=test_sku"`
+	}{
+		sku: "1",
+	}) {
+		t.Errorf("custom format does not validate")
+	}
+
+	if nil == Validate(struct {
+		slug []string `validate:"> format=test_slug"`
+	}{
+		slug: []string{"Tenant"},
+	}) {
+		t.Errorf("custom format does not validate")
+	}
+
+	if nil != OverrideFormat("test_sku", formatAlpha) {
+		t.Errorf("override format does not override a format")
+	}
+
+	if nil != Validate(struct {
+		sku string `validate:"format=test_sku"`
+	}{
+		sku: "sku",
+	}) {
+		t.Errorf("custom format does not validate")
+	}
+}
+
+// memFileSystem is an in-memory file system, values tell if files are directories
+type memFileSystem map[string]bool
+
+func (fs memFileSystem) Stat(name string) (os.FileInfo, error) {
+	isDir, ok := fs[name]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+
+	return memFileInfo{name: filepath.Base(name), isDir: isDir}, nil
+}
+
+type memFileInfo struct {
+	name  string
+	isDir bool
+}
+
+func (fi memFileInfo) Name() string       { return fi.name }
+func (fi memFileInfo) Size() int64        { return 0 }
+func (fi memFileInfo) Mode() os.FileMode  { return 0 }
+func (fi memFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi memFileInfo) IsDir() bool        { return fi.isDir }
+func (fi memFileInfo) Sys() interface{}   { return nil }
+
+func TestFileSystem(t *testing.T) {
+	type Config struct {
+		Path string `validate:"format=file"`
+		Dir  string `validate:"format=dir"`
+	}
+
+	fs := memFileSystem{"etc/app.yaml": false, "var/data": true}
+	valid := Config{Path: "etc/app.yaml", Dir: "var/data"}
+	v := New(WithFileSystem(fs))
+
+	if err := v.Validate(valid); err != nil {
+		t.Errorf("file and dir formats do not use a file system: %v", err)
+	}
+
+	for _, c := range []Config{{Path: "var/data", Dir: "var/data"}, {Path: "etc/app.yaml", Dir: "etc/app.yaml"}, {Path: "a.go", Dir: "var/data"}} {
+		if nil == v.Validate(c) {
+			t.Errorf("file and dir formats do not validate %+v", c)
+		}
+	}
+
+	if nil == Validate(valid) {
+		t.Errorf("validators share a file system")
+	}
+
+	defer SetFileSystem(nil)
+
+	SetFileSystem(fs)
+
+	if nil != Validate(valid) {
+		t.Errorf("validator does not use its file system")
+	}
+
+	SetFileSystem(nil)
+
+	if nil != Validate(Config{Path: filepath.Join("testdata", "a.go"), Dir: "testdata"}) {
+		t.Errorf("validator does not restore the file system of the operating system")
+	}
+}
@@ -0,0 +1,349 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// GeneratedValidator is implemented by struct types with validation code generated by validategen.
+// Generated code is equivalent to validation performed using reflection with package level configuration.
+type GeneratedValidator interface {
+
+	// ValidateCustom calls a custom validator of a type if it has one.
+	// It is called instead of Validate, which is generated by validategen.
+	ValidateCustom() error
+
+	// ValidateFields validates fields of a struct without reflection.
+	ValidateFields(s *State) error
+}
+
+// State is a state of a validation run used by generated code.
+// It tracks a path to a validated value and reports errors the same way Validate does.
+// A path does not need to be restored when an error is returned, since validation stops at the first error.
+type State struct {
+	r *validation
+}
+
+// NewState creates a state of a validation run, which stops at the first error.
+// Package level configuration is used to check formats and to validate values using reflection.
+func NewState() *State {
+	return &State{&validation{validator: defaultValidator}}
+}
+
+// PushField adds a struct field to a path to a validated value.
+// Custom validators are not called for values of unexported fields.
+func (s *State) PushField(name string, exported bool) {
+	segment := PathSegment{Type: PathSegmentField, Name: name}
+	if exported {
+		s.r.push(segment, nil)
+	} else {
+		s.r.pushUnexported(segment)
+	}
+}
+
+// PushIndex adds an element of a slice or an array to a path to a validated value.
+func (s *State) PushIndex(index int) {
+	s.r.push(PathSegment{Type: PathSegmentIndex, Index: index}, nil)
+}
+
+// PushKey adds a key or a value of a map to a path to a validated value.
+// A key is rendered only when an error occurs.
+func (s *State) PushKey(key interface{}) {
+	s.r.push(PathSegment{Type: PathSegmentKey}, key)
+}
+
+// PushPointer adds a dereferenced pointer to a path to a validated value.
+func (s *State) PushPointer() {
+	s.r.push(PathSegment{Type: PathSegmentPointer}, nil)
+}
+
+// Pop removes the last step from a path to a validated value.
+func (s *State) Pop() {
+	s.r.pop()
+}
+
+// Exported reports whether a validated value is reached using exported fields only.
+// Custom validators are called only in such a case.
+func (s *State) Exported() bool {
+	return s.r.unexported == 0
+}
+
+// Context gets a context passed to custom validators implementing ContextValidator.
+func (s *State) Context() context.Context {
+	return s.r.context()
+}
+
+// Report reports an error returned by a custom validator.
+// It returns the error if validation should stop or nil otherwise.
+func (s *State) Report(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return s.r.report(err)
+}
+
+// Fail reports ErrorValidation of a value which does not pass a validator.
+// It returns the error if validation should stop or nil otherwise.
+func (s *State) Fail(value interface{}, validatorType ValidatorType, validatorValue string) error {
+	return s.r.reportField(ErrorValidation{
+		fieldValue:     reflect.ValueOf(value),
+		validatorType:  validatorType,
+		validatorValue: validatorValue,
+	})
+}
+
+// FailNegated reports ErrorValidation of a value which passes a validator negated using ! operator.
+// It returns the error if validation should stop or nil otherwise.
+func (s *State) FailNegated(value interface{}, validatorType ValidatorType, validatorValue string) error {
+	return s.r.reportField(ErrorValidation{
+		fieldValue:     reflect.ValueOf(value),
+		validatorType:  validatorType,
+		validatorValue: validatorValue,
+		negated:        true,
+	})
+}
+
+// Format checks if a string is in a given format.
+func (s *State) Format(formatType FormatType, value string) bool {
+	s.r.validator.mutex.RLock()
+	formatFunc, ok := s.r.validator.formats[formatType]
+	s.r.validator.mutex.RUnlock()
+
+	return ok && formatFunc(value)
+}
+
+// FoldCase folds case of a string the same way case-insensitive string validators do, e.g. contains_fold.
+func (s *State) FoldCase(value string) string {
+	return foldCase(value)
+}
+
+// StringLength counts a length of a string in the unit used by comparison validators.
+func (s *State) StringLength(value string) int {
+	s.r.validator.mutex.RLock()
+	unit := s.r.validator.stringLength
+	s.r.validator.mutex.RUnlock()
+
+	return unit.count(value)
+}
+
+// Validate validates a field a pointer points to using reflection and given validators.
+// Parent is a pointer to a struct containing the field, it is used by cross-field validators.
+// Generated code uses it for values which could not be validated without reflection.
+func (s *State) Validate(parent interface{}, pointer interface{}, validators string) error {
+	parentValue := reflect.ValueOf(parent).Elem()
+	value := reflect.ValueOf(pointer).Elem()
+
+	// Values reached through unexported fields are read-only the same way they are when validated using reflection
+	if s.r.unexported > 0 {
+		value = readOnly(pointer)
+		if s.r.unexported > 1 || !s.r.path[len(s.r.path)-1].unexported {
+			parentValue = readOnly(parent)
+		}
+	}
+
+	previous := s.r.parent
+	s.r.parent = parentValue
+	err := s.r.validateValue(value, s.r.validator.getPlan(value.Type(), validators, parentValue.Type()))
+	s.r.parent = previous
+
+	return err
+}
+
+// readOnlyPointer holds a pointer in an unexported field, so a value it points to is read-only
+type readOnlyPointer struct {
+	pointer interface{}
+}
+
+// readOnly gets a read-only value a pointer points to
+func readOnly(pointer interface{}) reflect.Value {
+	return reflect.ValueOf(readOnlyPointer{pointer}).Field(0).Elem().Elem()
+}
+
+// CrossCheck validates a struct value using generated code and using reflection
+// with package level configuration, both stopping at the first error and collecting all errors.
+// It returns an error describing a difference of results or nil if results are the same.
+//
+//  if err := validate.CrossCheck(user); err != nil {
+//  	t.Error(err)
+//  }
+func CrossCheck(element GeneratedValidator) error {
+	for _, all := range []bool{false, true} {
+		s := &State{&validation{validator: defaultValidator, all: all}}
+
+		generated := s.Report(element.ValidateCustom())
+		if generated == nil {
+			generated = element.ValidateFields(s)
+		}
+
+		var reflective error
+		if all {
+			if len(s.r.errors) > 0 {
+				generated = s.r.errors
+			}
+			reflective = defaultValidator.ValidateAll(element)
+		} else {
+			reflective = defaultValidator.Validate(element)
+		}
+
+		if !sameErrors(generated, reflective) {
+			return fmt.Errorf("generated validation of %T returned \"%v\", while validation using reflection returned \"%v\"", element, generated, reflective)
+		}
+	}
+
+	return nil
+}
+
+// sameErrors checks if errors returned by generated code and reflection are the same
+func sameErrors(a, b error) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || a.Error() != b.Error() {
+		return false
+	}
+
+	switch a := a.(type) {
+	case Errors:
+		b := b.(Errors)
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !sameErrors(a[i], b[i]) {
+				return false
+			}
+		}
+	case ErrorValidation:
+		b := b.(ErrorValidation)
+		return a.fieldName == b.fieldName &&
+			reflect.DeepEqual(a.fieldPath, b.fieldPath) &&
+			a.fieldValue.Type() == b.fieldValue.Type() &&
+			fmt.Sprint(a.fieldValue) == fmt.Sprint(b.fieldValue) &&
+			a.validatorType == b.validatorType &&
+			a.validatorValue == b.validatorValue &&
+			a.negated == b.negated
+	case ErrorSyntax:
+		return reflect.DeepEqual(a, b.(ErrorSyntax))
+	}
+
+	return true
+}
+
+// Tag is a parsed tag of a struct field.
+// It is used by tools generating code from tags, e.g. validategen.
+type Tag struct {
+	// Condition is conditions of a guard or nil if validators are always performed.
+	Condition *TagExpression
+
+	// Validators are value validators or nil if there are no value validators.
+	Validators *TagExpression
+
+	// Key is a tag of map keys or nil if there are no key validators.
+	Key *Tag
+
+	// Elem is a tag of map values, slice and array elements, and dereferenced pointers
+	// or nil if there are no validators of the next level.
+	Elem *Tag
+}
+
+// TagExpression is an expression of a tag, i.e. a single validator or expressions combined by & or | operator.
+// Negation is applied to single validators only, e.g. !(a & b) is parsed as !a | !b.
+type TagExpression struct {
+	// Operator is '&' or '|' for combined expressions or 0 for a single validator.
+	Operator byte
+
+	// Operands are combined expressions.
+	Operands []*TagExpression
+
+	// Validator is a single validator.
+	Validator TagValidator
+}
+
+// TagValidator is a validator of a tag.
+type TagValidator struct {
+	Type  ValidatorType
+	Value string
+
+	// Negated is true if a validator is negated using ! operator
+	Negated bool
+}
+
+// ParseTag parses validators of a tag the same way Validate does.
+// It returns ErrorSyntax if a tag could not be parsed.
+// It does not check if validators exist and could be applied to a type, use CheckTag for that.
+func ParseTag(validators string) (*Tag, error) {
+	keyValidators, valueValidators, validators, err := splitValidators(validators)
+	if err != nil {
+		return nil, err
+	}
+
+	var conditions *node
+	if guard, expr, ok := splitGuard(valueValidators); ok {
+		if conditions, err = parseGuard(guard); err != nil {
+			return nil, err
+		}
+		valueValidators = expr
+	}
+
+	n, err := parseValidators(valueValidators)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := &Tag{
+		Condition:  tagExpression(conditions),
+		Validators: tagExpression(n),
+	}
+
+	if len(keyValidators) > 0 {
+		key, err := ParseTag(keyValidators)
+		if err != nil {
+			return nil, err
+		}
+		tag.Key = key
+	}
+
+	if len(validators) > 0 {
+		elem, err := ParseTag(validators)
+		if err != nil {
+			return nil, err
+		}
+		tag.Elem = elem
+	}
+
+	return tag, nil
+}
+
+// ParseTokens splits a validator value into tokens the same way one_of does.
+// Tokens are separated by commas outside of quoted strings, quotes are removed and escaped characters are unescaped.
+// It is used by tools generating code from tags, e.g. validategen.
+func ParseTokens(value string) []string {
+	tokens := parseTokens(value)
+
+	strs := make([]string, len(tokens))
+	for i, token := range tokens {
+		strs[i] = token.(string)
+	}
+
+	return strs
+}
+
+// tagExpression converts a parsed expression into an expression of a tag
+func tagExpression(n *node) *TagExpression {
+	if n == nil {
+		return nil
+	}
+
+	expression := &TagExpression{
+		Operator:  n.op,
+		Validator: TagValidator(n.validator),
+	}
+	for i := range n.operands {
+		expression.Operands = append(expression.Operands, tagExpression(&n.operands[i]))
+	}
+
+	return expression
+}
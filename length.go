@@ -0,0 +1,77 @@
+package validate
+
+import (
+	"reflect"
+	"unicode"
+	"unicode/utf8"
+)
+
+// StringLength is a unit used to count a length of a string by comparison validators.
+type StringLength int
+
+// Following units are available.
+const (
+	// StringLengthBytes counts bytes of a string, it is used by default.
+	StringLengthBytes StringLength = iota
+
+	// StringLengthRunes counts Unicode code points of a string.
+	StringLengthRunes
+
+	// StringLengthGraphemes counts user-perceived characters of a string.
+	// Combining marks, variation selectors, emoji modifiers, characters joined by a zero width joiner,
+	// pairs of regional indicators (flags), and CR LF are counted as a single character.
+	StringLengthGraphemes
+)
+
+// zeroWidthJoiner joins characters into a single user-perceived character, e.g. in emoji sequences
+const zeroWidthJoiner = '\u200d'
+
+// count counts a length of a string
+func (unit StringLength) count(str string) int {
+	switch unit {
+	case StringLengthRunes:
+		return utf8.RuneCountInString(str)
+	case StringLengthGraphemes:
+		return countGraphemes(str)
+	}
+
+	return len(str)
+}
+
+// len gets a length of a value, a length of a string is counted in the unit
+func (unit StringLength) len(value reflect.Value) int {
+	if value.Kind() == reflect.String {
+		return unit.count(value.String())
+	}
+
+	return value.Len()
+}
+
+// countGraphemes counts user-perceived characters of a string approximating extended grapheme clusters
+func countGraphemes(str string) int {
+	count, paired := 0, false
+	prev := utf8.RuneError
+	for _, r := range str {
+		extends := count > 0 && (isGraphemeExtend(r) || prev == zeroWidthJoiner || prev == '\r' && r == '\n')
+		pairs := count > 0 && !paired && isRegionalIndicator(prev) && isRegionalIndicator(r)
+		if !extends && !pairs {
+			count++
+		}
+		paired = pairs
+		prev = r
+	}
+
+	return count
+}
+
+// isGraphemeExtend checks if a rune extends a previous user-perceived character
+func isGraphemeExtend(r rune) bool {
+	return r == zeroWidthJoiner ||
+		r >= 0x1f3fb && r <= 0x1f3ff || // emoji modifiers
+		unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc, unicode.Variation_Selector)
+}
+
+// isRegionalIndicator checks if a rune is a regional indicator, a pair of them is a flag
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
@@ -0,0 +1,131 @@
+package validate
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+)
+
+// Following types are numbers of arbitrary precision compared exactly.
+var (
+	jsonNumberType = reflect.TypeOf(json.Number(""))
+	bigIntType     = reflect.TypeOf((*big.Int)(nil))
+	bigFloatType   = reflect.TypeOf((*big.Float)(nil))
+	bigRatType     = reflect.TypeOf((*big.Rat)(nil))
+)
+
+// isBigNumber checks if a type is json.Number, *big.Int, *big.Float, or *big.Rat
+func isBigNumber(typ reflect.Type) bool {
+	return typ == jsonNumberType || typ == bigIntType || typ == bigFloatType || typ == bigRatType
+}
+
+// parseBigNumber parses a number of arbitrary precision, e.g. 1.5, 1e30, or 3/4
+func parseBigNumber(token string) (*big.Rat, bool) {
+	return new(big.Rat).SetString(token)
+}
+
+// compareBigNumber compares a value with a number, it returns -1, 0, or 1 if a value is less, equal, or greater.
+// A *big.Float value is compared exactly, e.g. 0.1 of float64 precision is greater than 0.1.
+// It returns false if a pointer is nil or json.Number is not a number.
+// It returns an error if a pointer is obtained from an unexported field, since a number it points to could not be read.
+func compareBigNumber(value reflect.Value, token *big.Rat, validatorType ValidatorType, validator string) (int, bool, ErrorField) {
+	if value.Type() == jsonNumberType {
+		number, ok := parseBigNumber(value.String())
+		if !ok {
+			return 0, false, nil
+		}
+		return number.Cmp(token), true, nil
+	}
+
+	if !value.CanInterface() {
+		return 0, false, ErrorSyntax{
+			expression: validator,
+			near:       string(validatorType),
+			comment:    "could not read a number of an unexported field",
+		}
+	}
+
+	if value.IsNil() {
+		return 0, false, nil
+	}
+
+	switch number := value.Interface().(type) {
+	case *big.Int:
+		return new(big.Rat).SetInt(number).Cmp(token), true, nil
+	case *big.Rat:
+		return number.Cmp(token), true, nil
+	case *big.Float:
+		if number.IsInf() {
+			return number.Sign(), true, nil
+		}
+		exact, _ := number.Rat(nil)
+		return exact.Cmp(token), true, nil
+	}
+
+	return 0, false, nil
+}
+
+// validateCompareBig compiles a comparison validator of a number of arbitrary precision, a nil pointer is not valid
+func validateCompareBig(validatorType ValidatorType, op compareOp, validator string) (checkFunc, ErrorField) {
+	token, ok := parseBigNumber(validator)
+	if !ok {
+		return nil, ErrorSyntax{
+			expression: validator,
+			near:       string(validatorType),
+			comment:    "could not parse or run",
+		}
+	}
+
+	return func(value reflect.Value, parent reflect.Value) ErrorField {
+		cmp, ok, err := compareBigNumber(value, token, validatorType, validator)
+		if err != nil {
+			return err
+		}
+		if !ok || !op.compareInt(int64(cmp), 0) {
+			return ErrorValidation{
+				fieldValue:     value,
+				validatorType:  validatorType,
+				validatorValue: validator,
+			}
+		}
+		return nil
+	}, nil
+}
+
+// validateOneOfBig compiles a one_of validator of a number of arbitrary precision, a nil pointer is not valid
+func validateOneOfBig(validator string) (checkFunc, ErrorField) {
+	errorSyntax := ErrorSyntax{
+		expression: validator,
+		near:       string(ValidatorOneOf),
+		comment:    "could not parse or run",
+	}
+
+	var tokens []*big.Rat
+	for _, token := range parseTokens(validator) {
+		number, ok := parseBigNumber(token.(string))
+		if !ok {
+			return nil, errorSyntax
+		}
+		tokens = append(tokens, number)
+	}
+	if len(tokens) == 0 {
+		return nil, errorSyntax
+	}
+
+	return func(value reflect.Value, parent reflect.Value) ErrorField {
+		for _, token := range tokens {
+			cmp, ok, err := compareBigNumber(value, token, ValidatorOneOf, validator)
+			if err != nil {
+				return err
+			}
+			if ok && cmp == 0 {
+				return nil
+			}
+		}
+		return ErrorValidation{
+			fieldValue:     value,
+			validatorType:  ValidatorOneOf,
+			validatorValue: validator,
+		}
+	}, nil
+}
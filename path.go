@@ -0,0 +1,82 @@
+package validate
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PathSegmentType is used for path segment type definitions.
+type PathSegmentType int
+
+// Following path segment types are available.
+const (
+	// PathSegmentField is a struct field.
+	PathSegmentField PathSegmentType = iota
+
+	// PathSegmentIndex is an element of a slice or an array.
+	PathSegmentIndex
+
+	// PathSegmentKey is a key or a value of a map.
+	PathSegmentKey
+
+	// PathSegmentPointer is a dereferenced pointer.
+	PathSegmentPointer
+)
+
+// PathSegment is a single step of a path.
+type PathSegment struct {
+	// Type is a type of a segment.
+	Type PathSegmentType
+
+	// Name is a name of a struct field, used by PathSegmentField.
+	Name string
+
+	// Index is an index of an element, used by PathSegmentIndex.
+	// It is -1 for any element when a type is checked.
+	Index int
+
+	// Key is a string representation of a map key, used by PathSegmentKey.
+	// It is "*" for any key when a type is checked.
+	Key string
+}
+
+// Path is a path to a validated value starting from the value passed to Validate.
+// E.g. a path of a field Zip in a value of a map Addresses located in a slice Users
+// is rendered as Users[3].Addresses[home].Zip.
+type Path []PathSegment
+
+// String renders a path.
+func (p Path) String() string {
+	var b strings.Builder
+
+	for _, segment := range p {
+		switch segment.Type {
+		case PathSegmentField:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(segment.Name)
+		case PathSegmentIndex:
+			if segment.Index < 0 {
+				b.WriteString("[*]")
+			} else {
+				b.WriteString("[" + strconv.Itoa(segment.Index) + "]")
+			}
+		case PathSegmentKey:
+			b.WriteString("[" + segment.Key + "]")
+		}
+	}
+
+	return b.String()
+}
+
+// fieldName gets a name of the last struct field of a path
+func (p Path) fieldName() string {
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i].Type == PathSegmentField {
+			return p[i].Name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,428 @@
+package validate
+
+import (
+	"reflect"
+)
+
+// plan is a compiled validation plan of a value of a given type for given validators.
+// Plans are immutable once compiled, so they are shared by concurrent validation runs.
+type plan struct {
+	typ    reflect.Type
+	kind   reflect.Kind
+	err    ErrorField // error of splitting validators
+	custom bool       // a value may implement CustomValidator or ContextValidator
+	expr   expression // value validators
+	key    *plan      // map keys
+	elem   *plan      // map values, slice and array elements, dereferenced pointers
+	fields *structPlan
+	tail   ErrorField // error of validators that could not be applied
+}
+
+// structPlan is a compiled validation plan of struct fields
+type structPlan struct {
+	fields []fieldPlan
+}
+
+// fieldPlan is a compiled validation plan of a struct field
+type fieldPlan struct {
+	index int
+	name  string
+	plan  *plan
+}
+
+// planKey is a key of a compiled plan
+type planKey struct {
+	typ        reflect.Type
+	validators string
+	parent     reflect.Type
+}
+
+// expression is a compiled expression of value validators
+type expression struct {
+	err   ErrorField
+	guard conditionFunc // conditions which should hold to perform validators
+	rule  *rule         // nil if there are no validators
+}
+
+// rule is a compiled validator or compiled rules combined by & or | operator
+type rule struct {
+	op        byte // '&', '|', or 0 for a single validator
+	rules     []rule
+	check     checkFunc
+	err       ErrorField // syntax error reported instead of performing a check
+	fatal     bool       // syntax error which stops performing other validators
+	validator validator
+}
+
+// customValidatorType and contextValidatorType are types of CustomValidator and ContextValidator interfaces
+var (
+	customValidatorType  = reflect.TypeOf((*CustomValidator)(nil)).Elem()
+	contextValidatorType = reflect.TypeOf((*ContextValidator)(nil)).Elem()
+)
+
+// getPlan gets a compiled plan of a type for given validators, compiling it on the first use.
+// Parent is a type of a struct containing a field or nil if it is not known.
+func (v *Validator) getPlan(typ reflect.Type, validators string, parent reflect.Type) *plan {
+	key := planKey{typ, validators, parent}
+
+	v.mutex.RLock()
+	p, ok := v.plans[key]
+	v.mutex.RUnlock()
+	if ok {
+		return p
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	return v.compilePlan(typ, validators, parent)
+}
+
+// resetPlans drops compiled plans after configuration changes, v.mutex must be held
+func (v *Validator) resetPlans() {
+	v.plans = make(map[planKey]*plan)
+	v.structPlans = make(map[reflect.Type]*structPlan)
+}
+
+// compilePlan compiles a plan of a type for given validators, v.mutex must be held.
+// Elements of slices, arrays, maps, and pointers share a parent with a field containing them.
+func (v *Validator) compilePlan(typ reflect.Type, validators string, parent reflect.Type) *plan {
+	key := planKey{typ, validators, parent}
+	if p, ok := v.plans[key]; ok {
+		return p
+	}
+
+	p := &plan{
+		typ:  typ,
+		kind: typ.Kind(),
+	}
+	v.plans[key] = p
+
+	// Get validators
+	keyValidators, valueValidators, validators, err := splitValidators(validators)
+	if err != nil {
+		p.err = err
+		return p
+	}
+
+	p.custom = p.kind == reflect.Interface ||
+		typ.Implements(customValidatorType) ||
+		reflect.PtrTo(typ).Implements(customValidatorType) ||
+		typ.Implements(contextValidatorType) ||
+		reflect.PtrTo(typ).Implements(contextValidatorType)
+
+	p.expr = v.compileExpression(typ, valueValidators, parent)
+
+	// Dive one level deep into arrays and pointers
+	switch p.kind {
+	case reflect.Struct:
+		p.fields = v.compileStructPlan(typ)
+	case reflect.Map:
+		p.key = v.compilePlan(typ.Key(), keyValidators, parent)
+		p.elem = v.compilePlan(typ.Elem(), validators, parent)
+	case reflect.Slice, reflect.Array, reflect.Ptr:
+		p.elem = v.compilePlan(typ.Elem(), validators, parent)
+	}
+
+	if p.kind != reflect.Map && len(keyValidators) > 0 {
+		p.tail = ErrorSyntax{
+			expression: validators,
+			near:       "",
+			comment:    "unexpexted expression",
+		}
+	} else if p.elem == nil && len(validators) > 0 {
+		p.tail = ErrorSyntax{
+			expression: validators,
+			near:       "",
+			comment:    "unexpexted expression",
+		}
+	}
+
+	return p
+}
+
+// compileStructPlan compiles a plan of struct fields, v.mutex must be held
+func (v *Validator) compileStructPlan(typ reflect.Type) *structPlan {
+	if p, ok := v.structPlans[typ]; ok {
+		return p
+	}
+
+	p := &structPlan{
+		fields: make([]fieldPlan, typ.NumField()),
+	}
+	v.structPlans[typ] = p
+
+	// Iterate over struct fields
+	for i := range p.fields {
+		field := typ.Field(i)
+		p.fields[i] = fieldPlan{
+			index: i,
+			name:  v.fieldNameFunc(field),
+			plan:  v.compilePlan(field.Type, getValidators(field.Tag, v.tag), typ),
+		}
+	}
+
+	return p
+}
+
+// compileExpression parses and compiles value validators, v.mutex must be held
+func (v *Validator) compileExpression(typ reflect.Type, validators string, parent reflect.Type) expression {
+	var guard conditionFunc
+	if conditions, expr, ok := splitGuard(validators); ok {
+		var err ErrorField
+		if guard, err = compileGuard(conditions, parent); err != nil {
+			return expression{err: err}
+		}
+		validators = expr
+	}
+
+	n, err := parseValidators(validators)
+	if err != nil {
+		return expression{err: err}
+	}
+	if n == nil {
+		return expression{guard: guard}
+	}
+
+	r := v.compileRule(typ, *n, validators, parent)
+	return expression{guard: guard, rule: &r}
+}
+
+// compileRule compiles a validator or validators combined by & or | operator, v.mutex must be held
+func (v *Validator) compileRule(typ reflect.Type, n node, validators string, parent reflect.Type) rule {
+	if n.op != 0 {
+		rules := make([]rule, len(n.operands))
+		for i, operand := range n.operands {
+			rules[i] = v.compileRule(typ, operand, validators, parent)
+		}
+		return rule{op: n.op, rules: rules}
+	}
+
+	validator := n.validator
+	validatorFunc, ok := v.validators[validator.Type]
+	if !ok {
+		return rule{
+			err: ErrorSyntax{
+				expression: string(validator.Type),
+				near:       validators,
+				comment:    "could not find a validator",
+			},
+			fatal: true,
+		}
+	}
+
+	var check checkFunc
+	var err ErrorField
+	token := validator.token()
+	if v.textMarshaling && isText(typ, validator.Type) {
+		check, err = validateText(validatorFunc, validator.Type, typ, token, parent)
+	} else {
+		check, err = validatorFunc(typ, token, parent)
+	}
+	if check != nil && token != validator.Value {
+		check = reportValue(check, validator.Value)
+	}
+
+	return rule{check: check, err: err, validator: validator}
+}
+
+// reportValue makes errors of a check func report a validator value as it is written in a tag, i.e. with quotes
+func reportValue(check checkFunc, validator string) checkFunc {
+	return func(value reflect.Value, parent reflect.Value) ErrorField {
+		err := check(value, parent)
+		if e, ok := err.(ErrorValidation); ok {
+			e.validatorValue = validator
+			return e
+		}
+		return err
+	}
+}
+
+// check performs validators of an expression, parent is a struct containing a validated field
+func (e expression) check(value reflect.Value, parent reflect.Value) ErrorField {
+	if e.err != nil {
+		return e.err
+	}
+
+	if e.guard != nil {
+		if holds, err := e.guard(parent); err != nil || !holds {
+			return err
+		}
+	}
+
+	if e.rule == nil {
+		return nil
+	}
+
+	err, _ := e.rule.evaluate(value, parent)
+	return err
+}
+
+// evaluate performs rules in order until the result is known and reports an error of the rule which decided it.
+// Rules combined by & operator report the first failed rule, rules combined by | operator report the last one.
+// It returns true if a fatal syntax error stops performing other rules.
+func (r *rule) evaluate(value reflect.Value, parent reflect.Value) (ErrorField, bool) {
+	switch r.op {
+	case '&':
+		for i := range r.rules {
+			if err, fatal := r.rules[i].evaluate(value, parent); err != nil {
+				return err, fatal
+			}
+		}
+		return nil, false
+	case '|':
+		var err ErrorField
+		for i := range r.rules {
+			var fatal bool
+			if err, fatal = r.rules[i].evaluate(value, parent); err == nil || fatal {
+				return err, fatal
+			}
+		}
+		return err, false
+	}
+
+	if r.err != nil {
+		return r.err, r.fatal
+	}
+
+	return r.perform(value, parent), false
+}
+
+// errors collects syntax errors of a rule and rules it combines
+func (r *rule) errors() []ErrorField {
+	if r.op == 0 {
+		if r.err != nil {
+			return []ErrorField{r.err}
+		}
+		return nil
+	}
+
+	var errs []ErrorField
+	for i := range r.rules {
+		errs = append(errs, r.rules[i].errors()...)
+	}
+
+	return errs
+}
+
+// perform performs a validator of a rule, a negated validator fails if a value passes it
+func (r rule) perform(value reflect.Value, parent reflect.Value) ErrorField {
+	err := r.check(value, parent)
+	if !r.validator.Negated {
+		return err
+	}
+
+	switch err.(type) {
+	case nil:
+		return ErrorValidation{
+			fieldValue:     value,
+			validatorType:  r.validator.Type,
+			validatorValue: r.validator.Value,
+			negated:        true,
+		}
+	case ErrorValidation:
+		return nil
+	}
+
+	return err
+}
+
+// checkPlan collects syntax errors of a plan and plans it refers to.
+// Fields of a struct type are checked once to support recursive types.
+func (v *validation) checkPlan(p *plan, checked map[*structPlan]bool) {
+	if p.err != nil {
+		v.reportField(p.err)
+		return
+	}
+
+	if p.expr.err != nil {
+		v.reportField(p.expr.err)
+	}
+	if p.expr.rule != nil {
+		for _, err := range p.expr.rule.errors() {
+			v.reportField(err)
+		}
+	}
+
+	switch p.kind {
+	case reflect.Struct:
+		if !checked[p.fields] {
+			checked[p.fields] = true
+			for _, field := range p.fields.fields {
+				v.pushField(field.name)
+				v.checkPlan(field.plan, checked)
+				v.popField(field.name)
+			}
+		}
+	case reflect.Map:
+		v.push(PathSegment{Type: PathSegmentKey, Key: "*"}, nil)
+		v.checkPlan(p.key, checked)
+		v.checkPlan(p.elem, checked)
+		v.pop()
+	case reflect.Slice, reflect.Array:
+		v.push(PathSegment{Type: PathSegmentIndex, Index: -1}, nil)
+		v.checkPlan(p.elem, checked)
+		v.pop()
+	case reflect.Ptr:
+		v.push(PathSegment{Type: PathSegmentPointer}, nil)
+		v.checkPlan(p.elem, checked)
+		v.pop()
+	}
+
+	if p.tail != nil {
+		v.reportField(p.tail)
+	}
+}
+
+// checkSyntax collects syntax errors of validators when a type is not known.
+// It checks the grammar, validator types, and formats, v.mutex must be held.
+func (v *Validator) checkSyntax(r *validation, validators string) {
+	keyValidators, valueValidators, validators, err := splitValidators(validators)
+	if err != nil {
+		r.reportField(err)
+		return
+	}
+
+	if guard, expr, ok := splitGuard(valueValidators); ok {
+		if _, err := parseGuard(guard); err != nil {
+			r.reportField(err)
+		}
+		valueValidators = expr
+	}
+
+	if n, err := parseValidators(valueValidators); err != nil {
+		r.reportField(err)
+	} else if n != nil {
+		for _, validator := range n.validators() {
+			if _, ok := v.validators[validator.Type]; !ok {
+				r.reportField(ErrorSyntax{
+					expression: string(validator.Type),
+					near:       valueValidators,
+					comment:    "could not find a validator",
+				})
+			} else if _, ok := v.formats[FormatType(validator.token())]; !ok && validator.Type == ValidatorFormat {
+				r.reportField(ErrorSyntax{
+					expression: validator.token(),
+					near:       string(ValidatorFormat),
+					comment:    "could not find format",
+				})
+			} else if validator.Type == ValidatorRegexp {
+				if _, err := compileRegexp(validator.token()); err != nil {
+					r.reportField(err)
+				}
+			}
+		}
+	}
+
+	if len(keyValidators) > 0 {
+		r.push(PathSegment{Type: PathSegmentKey, Key: "*"}, nil)
+		v.checkSyntax(r, keyValidators)
+		r.pop()
+	}
+
+	if len(validators) > 0 {
+		r.push(PathSegment{Type: PathSegmentIndex, Index: -1}, nil)
+		v.checkSyntax(r, validators)
+		r.pop()
+	}
+}
@@ -0,0 +1,86 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// stringMatch checks if a string matches a token of a string validator
+type stringMatch func(value, token string) bool
+
+// stringValidatorFunc returns a validator func comparing a string with a validator value.
+// Case of both strings is folded if fold is set.
+func stringValidatorFunc(validatorType ValidatorType, match stringMatch, fold bool) validatorFunc {
+	return func(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+		if typ.Kind() != reflect.String {
+			return nil, ErrorSyntax{
+				expression: validator,
+				near:       string(validatorType),
+				comment:    "could not parse or run",
+			}
+		}
+
+		token := validator
+		if fold {
+			token = foldCase(token)
+		}
+
+		return func(value reflect.Value, parent reflect.Value) ErrorField {
+			str := value.String()
+			if fold {
+				str = foldCase(str)
+			}
+			if !match(str, token) {
+				return ErrorValidation{
+					fieldValue:     value,
+					validatorType:  validatorType,
+					validatorValue: validator,
+				}
+			}
+			return nil
+		}, nil
+	}
+}
+
+func stringIs(value, token string) bool {
+	return value == token
+}
+
+func stringIsNot(value, token string) bool {
+	return value != token
+}
+
+func stringBefore(value, token string) bool {
+	return value < token
+}
+
+func stringAfter(value, token string) bool {
+	return value > token
+}
+
+func stringExcludes(value, token string) bool {
+	return !strings.Contains(value, token)
+}
+
+func stringExcludesAll(value, token string) bool {
+	return !strings.ContainsAny(value, token)
+}
+
+func stringIsNotFold(value, token string) bool {
+	return !strings.EqualFold(value, token)
+}
+
+// foldCase maps every rune of a string to the smallest rune equivalent to it under simple Unicode case folding,
+// so strings are equal ignoring case, as strings.EqualFold reports, if their folded strings are equal
+func foldCase(value string) string {
+	return strings.Map(func(r rune) rune {
+		folded := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < folded {
+				folded = f
+			}
+		}
+		return folded
+	}, value)
+}
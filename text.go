@@ -0,0 +1,109 @@
+package validate
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+// textMarshalerType, stringerType, and stringType are types of encoding.TextMarshaler, fmt.Stringer, and string
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	stringType        = reflect.TypeOf("")
+)
+
+// textValidators are validators performed on a text of a value when text marshaling is enabled.
+// Comparison validators keep comparing numbers by their numeric value.
+var textValidators = map[ValidatorType]bool{ // validator type: whether numbers are validated as a text
+	ValidatorEq:           false,
+	ValidatorNe:           false,
+	ValidatorGt:           false,
+	ValidatorLt:           false,
+	ValidatorGte:          false,
+	ValidatorLte:          false,
+	ValidatorEmpty:        true,
+	ValidatorOneOf:        true,
+	ValidatorFormat:       true,
+	ValidatorRegexp:       true,
+	ValidatorIs:           true,
+	ValidatorIsNot:        true,
+	ValidatorBefore:       true,
+	ValidatorAfter:        true,
+	ValidatorContains:     true,
+	ValidatorExcludes:     true,
+	ValidatorPrefix:       true,
+	ValidatorSuffix:       true,
+	ValidatorContainsAny:  true,
+	ValidatorExcludesAll:  true,
+	ValidatorIsFold:       true,
+	ValidatorIsNotFold:    true,
+	ValidatorContainsFold: true,
+	ValidatorExcludesFold: true,
+	ValidatorPrefixFold:   true,
+	ValidatorSuffixFold:   true,
+}
+
+// isText checks if a value of a type is validated as a text by a validator, i.e. the type or a pointer to it implements
+// encoding.TextMarshaler or fmt.Stringer. Strings and types having their own support of comparison validators,
+// e.g. time.Duration, are validated as they are.
+func isText(typ reflect.Type, validatorType ValidatorType) bool {
+	numbers, ok := textValidators[validatorType]
+	if !ok || !numbers && getValueClass(typ) == classNumber {
+		return false
+	}
+
+	if typ.Kind() == reflect.String || typ == durationType || isTime(typ) || isBigNumber(typ) ||
+		implements(typ, comparableType) || implements(typ, lengtherType) {
+		return false
+	}
+
+	return implements(typ, textMarshalerType) || implements(typ, stringerType)
+}
+
+// validateText compiles a validator of a text of a value, encoding.TextMarshaler is preferred to fmt.Stringer.
+// Errors report the original value, a nil pointer and a value which could not be marshaled are not valid.
+func validateText(validatorFunc validatorFunc, validatorType ValidatorType, typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	check, err := validatorFunc(stringType, validator, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	iface := stringerType
+	if implements(typ, textMarshalerType) {
+		iface = textMarshalerType
+	}
+
+	return func(value reflect.Value, parent reflect.Value) ErrorField {
+		textValue, ok, errorField := interfaceOf(value, iface, validatorType, validator)
+		if errorField != nil {
+			return errorField
+		}
+
+		var text []byte
+		var err error
+		if ok {
+			if marshaler, isMarshaler := textValue.(encoding.TextMarshaler); isMarshaler {
+				text, err = marshaler.MarshalText()
+			} else {
+				text = []byte(textValue.(fmt.Stringer).String())
+			}
+		}
+
+		if err != nil || !ok {
+			return ErrorValidation{
+				fieldValue:     value,
+				validatorType:  validatorType,
+				validatorValue: validator,
+				err:            err,
+			}
+		}
+
+		errorField = check(reflect.ValueOf(string(text)), parent)
+		if e, isValidation := errorField.(ErrorValidation); isValidation {
+			e.fieldValue = value
+			return e
+		}
+		return errorField
+	}, nil
+}
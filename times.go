@@ -0,0 +1,180 @@
+package validate
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ClockFunc gets the current time used by validators comparing times with now.
+type ClockFunc func() time.Time
+
+// timeType is a type of time.Time
+var timeType = reflect.TypeOf(time.Time{})
+
+// compareTime compares times
+func (op compareOp) compareTime(a, b time.Time) bool {
+	switch op {
+	case compareEq:
+		return a.Equal(b)
+	case compareNe:
+		return !a.Equal(b)
+	case compareGt:
+		return a.After(b)
+	case compareLt:
+		return a.Before(b)
+	case compareGte:
+		return !a.Before(b)
+	case compareLte:
+		return !a.After(b)
+	}
+
+	return false
+}
+
+// isTime checks if a type is time.Time or a pointer to time.Time
+func isTime(typ reflect.Type) bool {
+	return typ == timeType || typ.Kind() == reflect.Ptr && typ.Elem() == timeType
+}
+
+// getTime gets a time of a time.Time value or a pointer to it, it returns false if a pointer is nil.
+// It returns an error if a value is obtained from an unexported field, since it could not be read.
+func getTime(value reflect.Value, validatorType ValidatorType, validator string) (time.Time, bool, ErrorField) {
+	if !value.CanInterface() {
+		return time.Time{}, false, errorUnexportedTime(validatorType, validator)
+	}
+
+	if t, ok := value.Interface().(*time.Time); ok {
+		if t == nil {
+			return time.Time{}, false, nil
+		}
+		return *t, true, nil
+	}
+
+	return value.Interface().(time.Time), true, nil
+}
+
+// errorUnexportedTime gets an error of a time.Time value obtained from an unexported field
+func errorUnexportedTime(validatorType ValidatorType, validator string) ErrorField {
+	return ErrorSyntax{
+		expression: validator,
+		near:       string(validatorType),
+		comment:    "could not read a time of an unexported field",
+	}
+}
+
+// parseTime parses a time in RFC 3339 format, a date, or a time relative to the current time, e.g. now-24h or now+30d.
+// It returns a func getting a time or nil if a time could not be parsed.
+func parseTime(literal string, clock ClockFunc) func() time.Time {
+	if literal == "now" {
+		return clock
+	}
+
+	if strings.HasPrefix(literal, "now+") || strings.HasPrefix(literal, "now-") {
+		offset, ok := parseOffset(literal[4:])
+		if !ok {
+			return nil
+		}
+		if literal[3] == '-' {
+			offset = -offset
+		}
+		return func() time.Time {
+			return clock().Add(offset)
+		}
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+		if t, err := time.Parse(layout, literal); err == nil {
+			return func() time.Time {
+				return t
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseOffset parses an offset of a relative time, a number of days may precede a duration, e.g. 30d or 1d12h
+func parseOffset(offset string) (time.Duration, bool) {
+	var days time.Duration
+	if i := strings.IndexByte(offset, 'd'); i >= 0 {
+		n, err := strconv.ParseUint(offset[:i], 10, 16)
+		if err != nil {
+			return 0, false
+		}
+		days, offset = time.Duration(n)*24*time.Hour, offset[i+1:]
+		if offset == "" {
+			return days, true
+		}
+	}
+
+	duration, err := time.ParseDuration(offset)
+	if err != nil || duration < 0 || strings.HasPrefix(offset, "+") {
+		return 0, false
+	}
+
+	return days + duration, true
+}
+
+// validateCompareTime compiles a comparison validator of a time.Time value or a pointer to it, a nil pointer is not valid
+func validateCompareTime(validatorType ValidatorType, op compareOp, typ reflect.Type, validator string, clock ClockFunc) (checkFunc, ErrorField) {
+	token := parseTime(validator, clock)
+	if token == nil {
+		return nil, ErrorSyntax{
+			expression: validator,
+			near:       string(validatorType),
+			comment:    "could not parse or run",
+		}
+	}
+
+	return func(value reflect.Value, parent reflect.Value) ErrorField {
+		t, ok, err := getTime(value, validatorType, validator)
+		if err != nil {
+			return err
+		}
+		if !ok || !op.compareTime(t, token()) {
+			return ErrorValidation{
+				fieldValue:     value,
+				validatorType:  validatorType,
+				validatorValue: validator,
+			}
+		}
+		return nil
+	}, nil
+}
+
+func (v *Validator) validatePast(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return validateNow(ValidatorPast, compareLt, typ, validator, v.clock)
+}
+
+func (v *Validator) validateFuture(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return validateNow(ValidatorFuture, compareGt, typ, validator, v.clock)
+}
+
+// validateNow compiles a validator checking if a time is (not) before or after the current time, a nil pointer is not valid
+func validateNow(validatorType ValidatorType, op compareOp, typ reflect.Type, validator string, clock ClockFunc) (checkFunc, ErrorField) {
+	expected, err := strconv.ParseBool(validator)
+	if err != nil || !isTime(typ) {
+		return nil, ErrorSyntax{
+			expression: validator,
+			near:       string(validatorType),
+			comment:    "could not parse or run",
+		}
+	}
+
+	return func(value reflect.Value, parent reflect.Value) ErrorField {
+		t, ok, err := getTime(value, validatorType, validator)
+		if err != nil {
+			return err
+		}
+		if !ok || op.compareTime(t, clock()) != expected {
+			return ErrorValidation{
+				fieldValue:     value,
+				validatorType:  validatorType,
+				validatorValue: validator,
+			}
+		}
+		return nil
+	}, nil
+}
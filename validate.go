@@ -1,12 +1,19 @@
 package validate
 
 import (
+	"context"
+	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
-// MasterTag is the main validation tag.
+// MasterTag is the main validation tag. It is used by default, use SetTag or WithTag to change it.
 const MasterTag = "validate"
 
 // CustomValidator is an interface for a validated struct.
@@ -18,6 +25,439 @@ type CustomValidator interface {
 	Validate() error
 }
 
+// ContextValidator is an interface for a validated struct which needs a context, e.g. to check request-scoped data.
+// It is used instead of CustomValidator if a struct implements both.
+type ContextValidator interface {
+
+	// ValidateContext is a custom validation function.
+	// A context passed to ValidateContext or context.Background() is used.
+	// ValidateContext does not work for nested types obtained from unexported field.
+	ValidateContext(ctx context.Context) error
+}
+
+// FieldNameFunc gets a name of a struct field that is used in errors.
+// An empty name inlines a field, i.e. its fields are added to a path without a segment of the field itself.
+type FieldNameFunc func(field reflect.StructField) string
+
+// TagFieldName returns a field name function which uses a name from a given tag, e.g. json, yaml, or form.
+// Options after a comma, such as omitempty, are ignored.
+// The Go field name is used if the tag is missing, its name is empty, or the tag is "-".
+// Embedded structs without a name in the tag are inlined as encoding/json does, e.g. Base.ID is reported as id.
+func TagFieldName(tag string) FieldNameFunc {
+	return func(field reflect.StructField) string {
+		value, ok := field.Tag.Lookup(tag)
+		if ok && value == "-" {
+			return field.Name
+		}
+
+		if i := strings.Index(value, ","); i >= 0 {
+			value = value[:i]
+		}
+		if value != "" {
+			return value
+		}
+
+		typ := field.Type
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if field.Anonymous && typ.Kind() == reflect.Struct {
+			return ""
+		}
+
+		return field.Name
+	}
+}
+
+// goFieldName gets a Go name of a struct field
+func goFieldName(field reflect.StructField) string {
+	return field.Name
+}
+
+// Validator validates values using its own configuration:
+// a tag name, registered validators and formats, a field name function, an error mode, a unit of a string length,
+// a clock, a file system, and a text marshaling mode.
+// Validators do not share configuration, so different libraries can use their own validators in the same program.
+// It is safe to use a Validator concurrently.
+type Validator struct {
+	tag            string
+	fieldNameFunc  FieldNameFunc
+	allErrors      bool
+	stringLength   StringLength
+	clock          ClockFunc
+	fileSystem     FileSystem
+	textMarshaling bool
+	validators     map[ValidatorType]validatorFunc
+	formats        map[FormatType]formatFunc
+	plans          map[planKey]*plan
+	structPlans    map[reflect.Type]*structPlan
+	mutex          sync.RWMutex
+}
+
+// Option configures a Validator.
+type Option func(v *Validator)
+
+// WithTag sets a name of the tag containing validators. MasterTag is used by default.
+func WithTag(tag string) Option {
+	return func(v *Validator) {
+		if tag != "" {
+			v.tag = tag
+		}
+	}
+}
+
+// WithFieldNameFunc sets a function to get names of struct fields used in errors. Go field names are used by default.
+func WithFieldNameFunc(f FieldNameFunc) Option {
+	return func(v *Validator) {
+		if f != nil {
+			v.fieldNameFunc = f
+		}
+	}
+}
+
+// WithAllErrors makes Validate collect all errors the same way ValidateAll does.
+func WithAllErrors() Option {
+	return func(v *Validator) {
+		v.allErrors = true
+	}
+}
+
+// WithStringLength sets a unit used to count a length of a string by comparison validators. Bytes are counted by default.
+func WithStringLength(unit StringLength) Option {
+	return func(v *Validator) {
+		v.stringLength = unit
+	}
+}
+
+// WithClock sets a function to get the current time used by validators comparing times with now, e.g. in tests.
+// time.Now is used by default.
+func WithClock(f ClockFunc) Option {
+	return func(v *Validator) {
+		if f != nil {
+			v.clock = f
+		}
+	}
+}
+
+// WithFileSystem sets a file system used by file and dir formats. The file system of the operating system is used by default.
+func WithFileSystem(fileSystem FileSystem) Option {
+	return func(v *Validator) {
+		if fileSystem != nil {
+			v.fileSystem = fileSystem
+		}
+	}
+}
+
+// WithTextMarshaling makes validators of strings validate values implementing encoding.TextMarshaler
+// or fmt.Stringer, e.g. net.IP, using their text. It is disabled by default.
+func WithTextMarshaling() Option {
+	return func(v *Validator) {
+		v.textMarshaling = true
+	}
+}
+
+// New creates a Validator with built-in validators and formats.
+//
+//  v := validate.New(validate.WithTag("check"), validate.WithFieldNameFunc(validate.TagFieldName("json")))
+//  v.RegisterFormat("sku", isSKU)
+//
+//  err := v.Validate(element)
+func New(options ...Option) *Validator {
+	v := &Validator{
+		tag:           MasterTag,
+		fieldNameFunc: goFieldName,
+		clock:         time.Now,
+		fileSystem:    osFileSystem{},
+	}
+	v.validators = v.getValidatorTypeMap()
+	v.formats = v.getFormatTypeMap()
+
+	for _, option := range options {
+		option(v)
+	}
+
+	v.resetPlans()
+
+	return v
+}
+
+// defaultValidator is used by package level functions
+var defaultValidator = New()
+
+// SetTag sets a name of the tag containing validators.
+// By default MasterTag is used. Pass an empty string to restore the default behavior.
+func (v *Validator) SetTag(tag string) {
+	if tag == "" {
+		tag = MasterTag
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v.tag = tag
+	v.resetPlans()
+}
+
+// SetTag sets a name of the tag containing validators used by package level functions.
+// By default MasterTag is used. Pass an empty string to restore the default behavior.
+// It allows using this package together with other packages relying on the validate tag.
+//
+//  validate.SetTag("vd")
+func SetTag(tag string) {
+	defaultValidator.SetTag(tag)
+}
+
+// SetFieldNameFunc sets a function to get names of struct fields used in errors.
+// By default Go field names are used. Pass nil to restore the default behavior.
+func (v *Validator) SetFieldNameFunc(f FieldNameFunc) {
+	if f == nil {
+		f = goFieldName
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v.fieldNameFunc = f
+	v.resetPlans()
+}
+
+// SetFieldNameFunc sets a function to get names of struct fields used in errors by package level functions.
+// By default Go field names are used. Pass nil to restore the default behavior.
+//
+//  validate.SetFieldNameFunc(validate.TagFieldName("json"))
+func SetFieldNameFunc(f FieldNameFunc) {
+	defaultValidator.SetFieldNameFunc(f)
+}
+
+// SetStringLength sets a unit used to count a length of a string by comparison validators.
+// By default bytes are counted.
+func (v *Validator) SetStringLength(unit StringLength) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v.stringLength = unit
+	v.resetPlans()
+}
+
+// SetStringLength sets a unit used to count a length of a string by comparison validators used by package level functions.
+// By default bytes are counted.
+//
+//  validate.SetStringLength(validate.StringLengthRunes)
+func SetStringLength(unit StringLength) {
+	defaultValidator.SetStringLength(unit)
+}
+
+// SetClock sets a function to get the current time used by validators comparing times with now.
+// By default time.Now is used. Pass nil to restore the default behavior.
+func (v *Validator) SetClock(f ClockFunc) {
+	if f == nil {
+		f = time.Now
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v.clock = f
+	v.resetPlans()
+}
+
+// SetClock sets a function to get the current time used by package level functions.
+// By default time.Now is used. Pass nil to restore the default behavior.
+//
+//  validate.SetClock(func() time.Time {
+//  	return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+//  })
+func SetClock(f ClockFunc) {
+	defaultValidator.SetClock(f)
+}
+
+// SetFileSystem sets a file system used by file and dir formats.
+// By default the file system of the operating system is used. Pass nil to restore the default behavior.
+func (v *Validator) SetFileSystem(fileSystem FileSystem) {
+	if fileSystem == nil {
+		fileSystem = osFileSystem{}
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v.fileSystem = fileSystem
+}
+
+// SetFileSystem sets a file system used by file and dir formats of package level functions.
+// By default the file system of the operating system is used. Pass nil to restore the default behavior.
+//
+//  validate.SetFileSystem(fstest.MapFS{"config/app.yaml": &fstest.MapFile{}})
+func SetFileSystem(fileSystem FileSystem) {
+	defaultValidator.SetFileSystem(fileSystem)
+}
+
+// SetTextMarshaling enables or disables validating values implementing encoding.TextMarshaler or fmt.Stringer
+// using their text. By default it is disabled.
+func (v *Validator) SetTextMarshaling(enabled bool) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v.textMarshaling = enabled
+	v.resetPlans()
+}
+
+// SetTextMarshaling enables or disables validating values implementing encoding.TextMarshaler or fmt.Stringer
+// using their text by package level functions. By default it is disabled.
+//
+//  validate.SetTextMarshaling(true)
+func SetTextMarshaling(enabled bool) {
+	defaultValidator.SetTextMarshaling(enabled)
+}
+
+// getFileSystem gets a file system used by file and dir formats
+func (v *Validator) getFileSystem() FileSystem {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+
+	return v.fileSystem
+}
+
+// Validate validates fields of a struct using configuration of the validator.
+// See the package level Validate function for details.
+func (v *Validator) Validate(element interface{}) error {
+	return v.validate(nil, element, v.allErrors)
+}
+
+// ValidateAll validates fields of a struct using configuration of the validator and collects all errors.
+// See the package level ValidateAll function for details.
+func (v *Validator) ValidateAll(element interface{}) error {
+	return v.validate(nil, element, true)
+}
+
+// ValidateContext validates fields of a struct using configuration of the validator and a given context.
+// See the package level ValidateContext function for details.
+func (v *Validator) ValidateContext(ctx context.Context, element interface{}) error {
+	return v.validate(ctx, element, v.allErrors)
+}
+
+// ValidateAllContext validates fields of a struct using configuration of the validator and a given context,
+// and collects all errors. See the package level ValidateAllContext function for details.
+func (v *Validator) ValidateAllContext(ctx context.Context, element interface{}) error {
+	return v.validate(ctx, element, true)
+}
+
+// validate validates a value stopping at the first error or collecting all errors, a context may be nil
+func (v *Validator) validate(ctx context.Context, element interface{}, all bool) error {
+	value := reflect.ValueOf(element)
+	if !value.IsValid() {
+		return nil
+	}
+
+	if ctx != nil {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	r := validation{validator: v, all: all, ctx: ctx}
+
+	// Only cancellation of a context stops collecting all errors
+	if err := r.validateValue(value, v.getPlan(value.Type(), "", nil)); err != nil {
+		return err
+	}
+	if len(r.errors) > 0 {
+		return r.errors
+	}
+
+	return nil
+}
+
+// CheckType checks tags of a type and all types it refers to without validating a value:
+// struct fields, slice and array elements, map keys and values, and pointers.
+// It returns Errors containing every ErrorSyntax found, including validators that could not be applied
+// to a type of a field, or nil if there are no syntax errors.
+// Paths of errors use index -1 and key "*" for elements of slices, arrays, and maps, e.g. Users[*].Zip.
+// Checked types are cached, so it also makes the first validation of a type faster.
+// A nil type has no tags, so nil is returned.
+func (v *Validator) CheckType(typ reflect.Type) error {
+	if typ == nil {
+		return nil
+	}
+
+	r := validation{all: true}
+
+	r.checkPlan(v.getPlan(typ, "", nil), make(map[*structPlan]bool))
+	if len(r.errors) > 0 {
+		return r.errors
+	}
+
+	return nil
+}
+
+// CheckTag checks validators of a tag for a field of a given type the same way CheckType does.
+// If a type is nil, only the syntax, validator types, and formats are checked.
+// It is used by tools checking tags without running a program, e.g. validatelint.
+// Fields referenced by cross-field validators, conditional validators, and guards are not checked, see CheckFieldTag.
+func (v *Validator) CheckTag(typ reflect.Type, validators string) error {
+	return v.CheckFieldTag(typ, validators, nil)
+}
+
+// CheckFieldTag checks validators of a tag for a field of a given type in a struct of a parent type the same way
+// CheckType does, so fields referenced by cross-field validators, conditional validators, and guards are found.
+// If a parent type is nil, referenced fields are not checked. If a type is nil, only the syntax, validator types,
+// and formats are checked.
+func (v *Validator) CheckFieldTag(typ reflect.Type, validators string, parent reflect.Type) error {
+	r := validation{all: true}
+
+	if typ != nil {
+		r.checkPlan(v.getPlan(typ, validators, parent), make(map[*structPlan]bool))
+	} else {
+		v.mutex.RLock()
+		v.checkSyntax(&r, validators)
+		v.mutex.RUnlock()
+	}
+
+	if len(r.errors) > 0 {
+		return r.errors
+	}
+
+	return nil
+}
+
+// MustCompile checks tags of a type of a value the same way CheckType does.
+// It panics if there are syntax errors, a nil value has no tags to check.
+func (v *Validator) MustCompile(element interface{}) {
+	if err := v.CheckType(reflect.TypeOf(element)); err != nil {
+		panic(err)
+	}
+}
+
+// CheckType checks tags of a type and all types it refers to without validating a value.
+// See Validator.CheckType for details.
+//
+//  err := validate.CheckType(reflect.TypeOf(S{}))
+func CheckType(typ reflect.Type) error {
+	return defaultValidator.CheckType(typ)
+}
+
+// CheckTag checks validators of a tag for a field of a given type.
+// See Validator.CheckTag for details.
+func CheckTag(typ reflect.Type, validators string) error {
+	return defaultValidator.CheckTag(typ, validators)
+}
+
+// CheckFieldTag checks validators of a tag for a field of a given type in a struct of a parent type.
+// See Validator.CheckFieldTag for details.
+func CheckFieldTag(typ reflect.Type, validators string, parent reflect.Type) error {
+	return defaultValidator.CheckFieldTag(typ, validators, parent)
+}
+
+// MustCompile checks tags of a type of a value and panics if there are syntax errors.
+// See Validator.MustCompile for details.
+//
+//  func init() {
+//  	validate.MustCompile(S{})
+//  }
+func MustCompile(element interface{}) {
+	defaultValidator.MustCompile(element)
+}
+
 // Validate validates fields of a struct.
 // It accepts a struct or a struct pointer as a parameter.
 // It returns an error if a struct does not validate or nil if there are no validation errors.
@@ -30,135 +470,254 @@ type CustomValidator interface {
 //
 //  // err contains an error
 func Validate(element interface{}) error {
-	value := reflect.ValueOf(element)
+	return defaultValidator.Validate(element)
+}
 
-	return validateField(value, "", "")
+// ValidateAll validates fields of a struct the same way Validate does, but it does not stop at the first error.
+// It walks the whole struct tree and returns Errors containing every error found
+// or nil if there are no validation errors.
+// Errors are ordered by struct field order, slice and array indices, and sorted map keys.
+//
+//  err := validate.ValidateAll(struct {
+//  	a int    `validate:"gte=0"`
+//  	b string `validate:"empty=false"`
+//  }{
+//  	a: -1,
+//  })
+//
+//  // err contains two errors
+func ValidateAll(element interface{}) error {
+	return defaultValidator.ValidateAll(element)
 }
 
-// validateField validates a struct field
-func validateField(value reflect.Value, fieldName string, validators string) error {
-	kind := value.Kind()
+// ValidateContext validates fields of a struct the same way Validate does using a given context.
+// The context is passed to custom validators implementing ContextValidator.
+// If the context is done, validation stops between elements of maps, slices, and arrays,
+// and the error of the context is returned.
+//
+//  ctx, cancel := context.WithTimeout(ctx, time.Second)
+//  defer cancel()
+//
+//  err := validate.ValidateContext(ctx, request)
+func ValidateContext(ctx context.Context, element interface{}) error {
+	return defaultValidator.ValidateContext(ctx, element)
+}
 
-	// Get validator type Map
-	validatorTypeMap := getValidatorTypeMap()
+// ValidateAllContext validates fields of a struct the same way ValidateAll does using a given context.
+// See ValidateContext for details.
+func ValidateAllContext(ctx context.Context, element interface{}) error {
+	return defaultValidator.ValidateAllContext(ctx, element)
+}
 
-	// Get validators
-	keyValidators, valueValidators, validators, err := splitValidators(validators)
-	if err != nil {
-		err = setFieldName(err, fieldName)
-		return err
+// validation holds a state of a single validation run
+type validation struct {
+	validator  *Validator
+	all        bool
+	errors     Errors
+	path       []pathStep
+	unexported int             // number of unexported fields in a path, custom validators are not called for their values
+	parent     reflect.Value   // struct containing a validated field
+	ctx        context.Context // context passed to custom validators or nil
+}
+
+// context gets a context passed to custom validators
+func (v *validation) context() context.Context {
+	if v.ctx == nil {
+		return context.Background()
 	}
 
-	// Call a custom validator
-	if err := callCustomValidator(value); err != nil {
-		return err
+	return v.ctx
+}
+
+// canceled gets an error of a context if it is done
+func (v *validation) canceled() error {
+	if v.ctx == nil {
+		return nil
 	}
 
-	// Parse validators
-	validatorsOr, err := parseValidators(valueValidators)
-	if err != nil {
-		err = setFieldName(err, fieldName)
-		return err
+	return v.ctx.Err()
+}
+
+// pathStep is a step of a path to a validated value, a map key is rendered only when an error occurs
+type pathStep struct {
+	segment    PathSegment
+	key        interface{}
+	unexported bool
+}
+
+// report handles an error and returns it if validation should stop
+func (v *validation) report(err error) error {
+	if v.all {
+		v.errors = append(v.errors, err)
+		return nil
 	}
 
-	// Perform validators
-	for _, validatorsAnd := range validatorsOr {
-		for _, validator := range validatorsAnd {
-			if validatorFunc, ok := validatorTypeMap[validator.Type]; ok {
-				if err = validatorFunc(value, validator.Value); err != nil {
-					err = setFieldName(err, fieldName)
-					break
-				}
-			} else {
-				return ErrorSyntax{
-					fieldName:  fieldName,
-					expression: string(validator.Type),
-					near:       valueValidators,
-					comment:    "could not find a validator",
-				}
-			}
+	return err
+}
+
+// reportField sets a path to a field error, handles it and returns it if validation should stop
+func (v *validation) reportField(err ErrorField) error {
+	return v.report(setFieldPath(err, v.fieldPath()))
+}
+
+// fieldPath gets a path to a validated value
+func (v *validation) fieldPath() Path {
+	if len(v.path) == 0 {
+		return nil
+	}
+
+	path := make(Path, len(v.path))
+	for i, step := range v.path {
+		path[i] = step.segment
+		if step.key != nil {
+			path[i].Key = fmt.Sprint(step.key)
 		}
-		if err == nil {
-			break
+	}
+
+	return path
+}
+
+// push adds a step to a path to a validated value
+func (v *validation) push(segment PathSegment, key interface{}) {
+	v.path = append(v.path, pathStep{segment: segment, key: key})
+}
+
+// pushUnexported adds a step of an unexported struct field to a path to a validated value
+func (v *validation) pushUnexported(segment PathSegment) {
+	v.path = append(v.path, pathStep{segment: segment, unexported: true})
+	v.unexported++
+}
+
+// pushField adds a step of a struct field to a path to a validated value, an inlined field with an empty name is skipped
+func (v *validation) pushField(name string) {
+	if name != "" {
+		v.push(PathSegment{Type: PathSegmentField, Name: name}, nil)
+	}
+}
+
+// popField removes a step of a struct field added by pushField
+func (v *validation) popField(name string) {
+	if name != "" {
+		v.pop()
+	}
+}
+
+// pop removes the last step from a path to a validated value
+func (v *validation) pop() {
+	if v.path[len(v.path)-1].unexported {
+		v.unexported--
+	}
+	v.path = v.path[:len(v.path)-1]
+}
+
+// validateValue validates a value using a compiled plan
+func (v *validation) validateValue(value reflect.Value, p *plan) error {
+	if p.err != nil {
+		return v.reportField(p.err)
+	}
+
+	// Call a custom validator
+	if p.custom && v.unexported == 0 {
+		if err := callCustomValidator(v.context(), value); err != nil {
+			if err := v.report(err); err != nil {
+				return err
+			}
 		}
 	}
-	if err != nil {
-		return err
+
+	// Perform validators
+	if err := p.expr.check(value, v.parent); err != nil {
+		if err := v.reportField(err); err != nil {
+			return err
+		}
 	}
 
 	// Dive one level deep into arrays and pointers
-	switch kind {
+	switch p.kind {
 	case reflect.Struct:
-		if err := validateStruct(value); err != nil {
-			return err
+		parent := v.parent
+		v.parent = value
+		for _, field := range p.fields.fields {
+			v.pushField(field.name)
+			err := v.validateValue(value.Field(field.index), field.plan)
+			v.popField(field.name)
+			if err != nil {
+				return err
+			}
 		}
+		v.parent = parent
 	case reflect.Map:
-		for _, key := range value.MapKeys() {
-			if err := validateField(key, fieldName, keyValidators); err != nil {
+		for _, key := range sortMapKeys(value.MapKeys()) {
+			if err := v.canceled(); err != nil {
 				return err
 			}
-			if err := validateField(value.MapIndex(key), fieldName, validators); err != nil {
+			v.push(PathSegment{Type: PathSegmentKey}, key)
+			err := v.validateValue(key, p.key)
+			if err == nil {
+				err = v.validateValue(value.MapIndex(key), p.elem)
+			}
+			v.pop()
+			if err != nil {
 				return err
 			}
 		}
 	case reflect.Slice, reflect.Array:
 		for i := 0; i < value.Len(); i++ {
-			if err := validateField(value.Index(i), fieldName, validators); err != nil {
+			if err := v.canceled(); err != nil {
+				return err
+			}
+			v.push(PathSegment{Type: PathSegmentIndex, Index: i}, nil)
+			err := v.validateValue(value.Index(i), p.elem)
+			v.pop()
+			if err != nil {
 				return err
 			}
 		}
 	case reflect.Ptr:
 		if !value.IsNil() {
-			if err := validateField(value.Elem(), fieldName, validators); err != nil {
+			v.push(PathSegment{Type: PathSegmentPointer}, nil)
+			err := v.validateValue(value.Elem(), p.elem)
+			v.pop()
+			if err != nil {
 				return err
 			}
 		}
 	}
 
-	if kind != reflect.Map {
-		if len(keyValidators) > 0 {
-			return ErrorSyntax{
-				fieldName:  fieldName,
-				expression: validators,
-				near:       "",
-				comment:    "unexpexted expression",
-			}
-		}
-	}
-
-	if kind != reflect.Map && kind != reflect.Slice && kind != reflect.Array && kind != reflect.Ptr {
-		if len(validators) > 0 {
-			return ErrorSyntax{
-				fieldName:  fieldName,
-				expression: validators,
-				near:       "",
-				comment:    "unexpexted expression",
-			}
-		}
+	if p.tail != nil {
+		return v.reportField(p.tail)
 	}
 
 	return nil
 }
 
-// validateStruct validates a struct
-func validateStruct(value reflect.Value) error {
-	typ := value.Type()
+// sortMapKeys sorts map keys to make the order of validation deterministic
+func sortMapKeys(keys []reflect.Value) []reflect.Value {
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
 
-	// Iterate over struct fields
-	for i := 0; i < typ.NumField(); i++ {
-		validators := getValidators(typ.Field(i).Tag)
-		fieldName := typ.Field(i).Name
-		if err := validateField(value.Field(i), fieldName, validators); err != nil {
-			return err
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		case reflect.Bool:
+			return !a.Bool() && b.Bool()
 		}
-	}
 
-	return nil
+		return fmt.Sprint(a) < fmt.Sprint(b)
+	})
+
+	return keys
 }
 
 // getValidators gets validators
-func getValidators(tag reflect.StructTag) string {
-	return tag.Get(MasterTag)
+func getValidators(tag reflect.StructTag, name string) string {
+	return tag.Get(name)
 }
 
 // splitValidators splits validators into key validators, value validators and remaning validators of the next level
@@ -172,6 +731,18 @@ func splitValidators(validators string) (keyValidators string, valValidators str
 loop:
 	for ; i < len(validators); i++ {
 		switch validators[i] {
+		case '\'', '"':
+			end := skipQuoted(validators, i)
+			if end < 0 {
+				err = ErrorSyntax{
+					expression: "",
+					near:       validators,
+					column:     utf8.RuneCountInString(validators[:i]) + 1,
+					comment:    "expected closing quote",
+				}
+				return
+			}
+			i = end - 1
 		case '>':
 			if bracket == 0 {
 				gt++
@@ -234,68 +805,325 @@ loop:
 	return
 }
 
-// parseValidator parses validators into the slice of slices.
-// First slice acts as AND logic, second array acts as OR logic.
-func parseValidators(validators string) (validatorsOr [][]validator, err ErrorField) {
-	regexpType := regexp.MustCompile(`[[:alnum:]_]+`)
-	regexpValue := regexp.MustCompile(`[^=\s]+[^=]*[^=\s]+|[^=\s]+`)
+// regexpType matches a validator type
+var regexpType = regexp.MustCompile(`[[:alnum:]_]+`)
 
-	if len(validators) == 0 {
-		return
+// node is a parsed expression of validators, i.e. a single validator or operands combined by & or | operator.
+// Negation is applied to single validators only, e.g. !(a & b) is parsed as !a | !b.
+type node struct {
+	op        byte // '&', '|', or 0 for a single validator
+	operands  []node
+	validator validator
+}
+
+// combine combines operands by an operator, operands combined by the same operator are merged
+func combine(op byte, operands []node) node {
+	merged := make([]node, 0, len(operands))
+	for _, operand := range operands {
+		if operand.op == op {
+			merged = append(merged, operand.operands...)
+		} else {
+			merged = append(merged, operand)
+		}
 	}
 
-	entriesOr := strings.Split(validators, "|")
-	validatorsOr = make([][]validator, 0, len(entriesOr))
-	for _, entryOr := range entriesOr {
-		entriesAnd := strings.Split(entryOr, "&")
-		validatorsAnd := make([]validator, 0, len(entriesAnd))
-		for _, entryOr := range entriesAnd {
-			entries := strings.Split(entryOr, "=")
-			if len(entries) == 0 || len(entries) > 2 {
-				err = ErrorSyntax{
-					expression: validators,
-					comment:    "could not parse",
-				}
-				return
-			}
-			t := regexpType.FindString(entries[0])
-			if len(t) == 0 {
-				err = ErrorSyntax{
-					expression: entries[0],
-					near:       validators,
-					comment:    "could not parse",
-				}
-				return
-			}
-			v := ""
-			if len(entries) == 2 {
-				v = regexpValue.FindString(entries[1])
+	if len(merged) == 1 {
+		return merged[0]
+	}
+
+	return node{op: op, operands: merged}
+}
+
+// negate negates an expression using De Morgan's laws, so only single validators are negated
+func (n node) negate() node {
+	switch n.op {
+	case '&', '|':
+		op := byte('&')
+		if n.op == '&' {
+			op = '|'
+		}
+		operands := make([]node, len(n.operands))
+		for i, operand := range n.operands {
+			operands[i] = operand.negate()
+		}
+		return combine(op, operands)
+	}
+
+	n.validator.Negated = !n.validator.Negated
+	return n
+}
+
+// validators gets single validators of an expression in order
+func (n node) validators() []validator {
+	if n.op == 0 {
+		return []validator{n.validator}
+	}
+
+	var validators []validator
+	for _, operand := range n.operands {
+		validators = append(validators, operand.validators()...)
+	}
+
+	return validators
+}
+
+// parseValidators parses an expression of validators into a tree, it returns nil if there are no validators.
+// Parentheses are used for grouping, ! operator has a priority over & operator, which has a priority over | operator.
+func parseValidators(validators string) (*node, ErrorField) {
+	p := &expressionParser{expression: validators}
+	if p.skipSpaces(); p.end() {
+		return nil, nil
+	}
+
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.end() {
+		return nil, p.errorAt(p.pos, fmt.Sprintf("unexpected %q", p.expression[p.pos]))
+	}
+
+	return &n, nil
+}
+
+// expressionParser is a recursive descent parser of an expression of validators
+type expressionParser struct {
+	expression string
+	pos        int
+}
+
+// end checks if the whole expression is parsed
+func (p *expressionParser) end() bool {
+	return p.pos >= len(p.expression)
+}
+
+// skipSpaces skips whitespace characters
+func (p *expressionParser) skipSpaces() {
+	for !p.end() && unicode.IsSpace(rune(p.expression[p.pos])) {
+		p.pos++
+	}
+}
+
+// next skips whitespace characters and checks if the next character is a given operator, consuming it if so
+func (p *expressionParser) next(operator byte) bool {
+	if p.skipSpaces(); !p.end() && p.expression[p.pos] == operator {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+// errorAt creates a syntax error at a given position of the expression
+func (p *expressionParser) errorAt(pos int, comment string) ErrorSyntax {
+	near := ""
+	if pos < len(p.expression) {
+		near = p.expression[pos : pos+1]
+	}
+
+	return ErrorSyntax{
+		expression: p.expression,
+		near:       near,
+		column:     utf8.RuneCountInString(p.expression[:pos]) + 1,
+		comment:    comment,
+	}
+}
+
+// parseOr parses validators separated by | operator
+func (p *expressionParser) parseOr() (node, ErrorField) {
+	var operands []node
+	for {
+		operand, err := p.parseAnd()
+		if err != nil {
+			return node{}, err
+		}
+		operands = append(operands, operand)
+
+		if !p.next('|') {
+			return combine('|', operands), nil
+		}
+	}
+}
+
+// parseAnd parses validators separated by & operator
+func (p *expressionParser) parseAnd() (node, ErrorField) {
+	var operands []node
+	for {
+		operand, err := p.parseOperand()
+		if err != nil {
+			return node{}, err
+		}
+		operands = append(operands, operand)
+
+		if !p.next('&') {
+			return combine('&', operands), nil
+		}
+	}
+}
+
+// parseOperand parses a negated operand, an expression in parentheses, or a single validator
+func (p *expressionParser) parseOperand() (node, ErrorField) {
+	p.skipSpaces()
+	start := p.pos
+
+	if p.next('!') {
+		operand, err := p.parseOperand()
+		if err != nil {
+			return node{}, err
+		}
+		return operand.negate(), nil
+	}
+
+	if p.next('(') {
+		operand, err := p.parseOr()
+		if err != nil {
+			return node{}, err
+		}
+		if !p.next(')') {
+			return node{}, p.errorAt(start, "expected \")\" to close \"(\"")
+		}
+		return operand, nil
+	}
+
+	for !p.end() && strings.IndexByte("&|()", p.expression[p.pos]) < 0 {
+		if c := p.expression[p.pos]; c == '\'' || c == '"' {
+			end := skipQuoted(p.expression, p.pos)
+			if end < 0 {
+				return node{}, p.errorAt(p.pos, "expected closing quote")
 			}
-			validatorsAnd = append(validatorsAnd, validator{ValidatorType(t), v})
+			p.pos = end
+			continue
 		}
-		if len(validatorsAnd) > 0 {
-			validatorsOr = append(validatorsOr, validatorsAnd)
+		p.pos++
+	}
+	if strings.TrimSpace(p.expression[start:p.pos]) == "" {
+		return node{}, p.errorAt(p.pos, "expected a validator")
+	}
+
+	v, err := parseValidator(p.expression[start:p.pos], p.expression)
+	if err != nil {
+		return node{}, err
+	}
+
+	return node{validator: v}, nil
+}
+
+// parseValidator parses a single validator of an expression, e.g. gte=10
+func parseValidator(entry string, validators string) (validator, ErrorField) {
+	entries := splitUnquoted(entry, '=')
+	if len(entries) > 2 {
+		return validator{}, ErrorSyntax{
+			expression: validators,
+			comment:    "could not parse",
 		}
 	}
 
-	return
+	t := regexpType.FindString(entries[0])
+	if len(t) == 0 {
+		return validator{}, ErrorSyntax{
+			expression: entries[0],
+			near:       validators,
+			comment:    "could not parse",
+		}
+	}
+
+	v := ""
+	if len(entries) == 2 {
+		v = strings.TrimSpace(entries[1])
+	}
+
+	return validator{Type: ValidatorType(t), Value: v}, nil
 }
 
 // parseTokens parses tokens into array
+// Tokens are separated by commas outside of quoted strings, quotes are removed from tokens.
 func parseTokens(str string) []interface{} {
-	tokenStrings := strings.Split(str, ",")
+	tokenStrings := splitUnquoted(str, ',')
 	tokens := make([]interface{}, 0, len(tokenStrings))
 
 	for i := range tokenStrings {
 		token := strings.TrimSpace(tokenStrings[i])
 		if token != "" {
-			tokens = append(tokens, token)
+			tokens = append(tokens, unquote(token))
 		}
 	}
 
 	return tokens
 }
 
+// skipQuoted gets an index after a quoted string starting at a given index or -1 if a string is not closed.
+// Strings are quoted using single or double quotes, a backslash escapes the next character.
+func skipQuoted(str string, i int) int {
+	quote := str[i]
+	for i++; i < len(str); i++ {
+		switch str[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+
+	return -1
+}
+
+// indexUnquoted gets an index of the first of given characters outside of quoted strings or -1
+func indexUnquoted(str string, chars string) int {
+	for i := 0; i < len(str); {
+		switch {
+		case str[i] == '\'' || str[i] == '"':
+			if i = skipQuoted(str, i); i < 0 {
+				return -1
+			}
+		case strings.IndexByte(chars, str[i]) >= 0:
+			return i
+		default:
+			i++
+		}
+	}
+
+	return -1
+}
+
+// splitUnquoted splits a string by a separator outside of quoted strings
+func splitUnquoted(str string, sep byte) []string {
+	var parts []string
+	for {
+		i := indexUnquoted(str, string(sep))
+		if i < 0 {
+			return append(parts, str)
+		}
+		parts = append(parts, str[:i])
+		str = str[i+1:]
+	}
+}
+
+// unquote removes quotes from quoted strings of a token and unescapes their characters, e.g. 'a,b' is a,b
+func unquote(token string) string {
+	if strings.IndexAny(token, "'\"") < 0 {
+		return token
+	}
+
+	var b strings.Builder
+	var quote byte
+	for i := 0; i < len(token); i++ {
+		switch c := token[i]; {
+		case quote == 0 && (c == '\'' || c == '"'):
+			quote = c
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0 && c == '\\' && i+1 < len(token):
+			i++
+			b.WriteByte(token[i])
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
 // tokenOneOf check if a token is one of tokens
 func tokenOneOf(token interface{}, tokens []interface{}) bool {
 	for _, t := range tokens {
@@ -308,11 +1136,30 @@ func tokenOneOf(token interface{}, tokens []interface{}) bool {
 }
 
 // Call a custom validator
-func callCustomValidator(value reflect.Value) error {
+func callCustomValidator(ctx context.Context, value reflect.Value) error {
 	if !value.CanInterface() {
 		return nil
 	}
 
+	// ValidateContext is never generated, so it is called for types with generated code as well
+	if contextValidator, ok := value.Interface().(ContextValidator); ok {
+		return contextValidator.ValidateContext(ctx)
+	}
+	if reflect.PtrTo(value.Type()).Implements(contextValidatorType) {
+		valueCopyPointer := reflect.New(value.Type())
+		valueCopyPointer.Elem().Set(value)
+		return valueCopyPointer.Interface().(ContextValidator).ValidateContext(ctx)
+	}
+
+	// Validate of a type with generated code is generated, call a custom validator the type may have instead.
+	// Generated methods have value receivers, so they are not called for nil pointers.
+	if generatedValidator, ok := value.Interface().(GeneratedValidator); ok {
+		if value.Kind() == reflect.Ptr && value.IsNil() {
+			return nil
+		}
+		return generatedValidator.ValidateCustom()
+	}
+
 	// Following code won't work in case if Validate is implemented by reference and value is passed by value
 	if customValidator, ok := value.Interface().(CustomValidator); ok {
 		return customValidator.Validate()
@@ -1,8 +1,16 @@
 package validate
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 )
@@ -39,98 +47,134 @@ func TestSplitValidators(t *testing.T) {
 	if key != "[val_c=c] > val_d=d" || val != "val_a=a val_b=b   val_e=e " || validators != " val_f=f [val_g=g] > val_h=h" {
 		t.Errorf("splitValidators incorrectly splits validators")
 	}
+
+	key, val, validators, _ = splitValidators(`val_a='>[' [val_b="]\">"] > val_c=c`)
+	if key != `val_b="]\">"` || val != "val_a='>['   " || validators != " val_c=c" {
+		t.Errorf("splitValidators incorrectly splits quoted validators")
+	}
+
+	if _, _, _, err := splitValidators("val_a='a > val_b=b"); err == nil {
+		t.Errorf("splitValidators does not report an unclosed quote")
+	}
 }
 
 func TestParseValidators(t *testing.T) {
-	var validatorsOr [][]validator
+	leaf := func(validatorType ValidatorType, value string, negated bool) node {
+		return node{validator: validator{validatorType, value, negated}}
+	}
 
-	validatorsOr, _ = parseValidators("")
-	if len(validatorsOr) > 0 {
+	if n, err := parseValidators(""); n != nil || err != nil {
 		t.Errorf("parseValidators incorrectly parses validators")
 	}
 
-	validatorsOr, _ = parseValidators("&|&,&")
-	if len(validatorsOr) > 0 {
+	if n, err := parseValidators("&|&,&"); n != nil || err == nil {
 		t.Errorf("parseValidators incorrectly parses validators")
 	}
 
-	validatorsOr, _ = parseValidators("val_a=a")
-	if !reflect.DeepEqual(validatorsOr, [][]validator{
-		[]validator{
-			validator{
-				ValidatorType("val_a"),
-				"a",
-			},
-		},
-	}) {
+	n, _ := parseValidators("val_a=a")
+	if n == nil || !reflect.DeepEqual(*n, leaf("val_a", "a", false)) {
 		t.Errorf("parseValidators incorrectly parses validators")
 	}
 
-	validatorsOr, _ = parseValidators("  val  |val_a=a|val_1 = 1  |  val_b = b , c_d_ , 1.0  |VAL = V A L U E ¶  ")
-	if !reflect.DeepEqual(validatorsOr, [][]validator{
-		[]validator{
-			validator{
-				ValidatorType("val"),
-				"",
-			},
-		},
-		[]validator{
-			validator{
-				ValidatorType("val_a"),
-				"a",
-			},
-		},
-		[]validator{
-			validator{
-				ValidatorType("val_1"),
-				"1",
-			},
-		},
-		[]validator{
-			validator{
-				ValidatorType("val_b"),
-				"b , c_d_ , 1.0",
-			},
-		},
-		[]validator{
-			validator{
-				ValidatorType("VAL"),
-				"V A L U E ¶",
-			},
-		},
-	}) {
+	n, _ = parseValidators("  val  |val_a=a|val_1 = 1  |  val_b = b , c_d_ , 1.0  |VAL = V A L U E ¶  ")
+	if n == nil || !reflect.DeepEqual(*n, node{op: '|', operands: []node{
+		leaf("val", "", false),
+		leaf("val_a", "a", false),
+		leaf("val_1", "1", false),
+		leaf("val_b", "b , c_d_ , 1.0", false),
+		leaf("VAL", "V A L U E ¶", false),
+	}}) {
 		t.Errorf("parseValidators incorrectly parses validators")
 	}
 
-	validatorsOr, _ = parseValidators("  val  &val_a=a|val_1 = 1  &  val_b = b , c_d_ , 1.0  &VAL = V A L U E ¶  ")
-	if !reflect.DeepEqual(validatorsOr, [][]validator{
-		[]validator{
-			validator{
-				ValidatorType("val"),
-				"",
-			},
-			validator{
-				ValidatorType("val_a"),
-				"a",
-			},
-		},
-		[]validator{
-			validator{
-				ValidatorType("val_1"),
-				"1",
-			},
-			validator{
-				ValidatorType("val_b"),
-				"b , c_d_ , 1.0",
-			},
-			validator{
-				ValidatorType("VAL"),
-				"V A L U E ¶",
-			},
-		},
-	}) {
+	n, _ = parseValidators("  val  &val_a=a|val_1 = 1  &  val_b = b , c_d_ , 1.0  &VAL = V A L U E ¶  ")
+	if n == nil || !reflect.DeepEqual(*n, node{op: '|', operands: []node{
+		{op: '&', operands: []node{
+			leaf("val", "", false),
+			leaf("val_a", "a", false),
+		}},
+		{op: '&', operands: []node{
+			leaf("val_1", "1", false),
+			leaf("val_b", "b , c_d_ , 1.0", false),
+			leaf("VAL", "V A L U E ¶", false),
+		}},
+	}}) {
 		t.Errorf("parseValidators incorrectly parses validators")
 	}
+
+	n, _ = parseValidators(" ( a=1 & b=2 | c=3 ) & ((d)) ")
+	if n == nil || !reflect.DeepEqual(*n, node{op: '&', operands: []node{
+		{op: '|', operands: []node{
+			{op: '&', operands: []node{leaf("a", "1", false), leaf("b", "2", false)}},
+			leaf("c", "3", false),
+		}},
+		leaf("d", "", false),
+	}}) {
+		t.Errorf("parseValidators incorrectly parses parentheses")
+	}
+
+	n, _ = parseValidators("(a | b) & (c | d) & (e & f)")
+	if n == nil || !reflect.DeepEqual(*n, node{op: '&', operands: []node{
+		{op: '|', operands: []node{leaf("a", "", false), leaf("b", "", false)}},
+		{op: '|', operands: []node{leaf("c", "", false), leaf("d", "", false)}},
+		leaf("e", "", false),
+		leaf("f", "", false),
+	}}) {
+		t.Errorf("parseValidators incorrectly parses parentheses")
+	}
+
+	n, _ = parseValidators(strings.Repeat("(gte=0 | lte=100) & ", 63) + "(gte=0 | lte=100)")
+	if n == nil || len(n.operands) != 64 || len(n.validators()) != 128 {
+		t.Errorf("parseValidators does not keep the size of an expression")
+	}
+
+	n, _ = parseValidators(`a = 'x=y|(z)' & b="&"`)
+	if n == nil || !reflect.DeepEqual(*n, node{op: '&', operands: []node{
+		leaf("a", "'x=y|(z)'", false),
+		leaf("b", `"&"`, false),
+	}}) {
+		t.Errorf("parseValidators incorrectly parses quoted values")
+	}
+
+	if _, err := parseValidators("a='x & b"); err == nil {
+		t.Errorf("parseValidators does not report an unclosed quote")
+	}
+
+	n, _ = parseValidators("!(a & !b) | c")
+	if n == nil || !reflect.DeepEqual(*n, node{op: '|', operands: []node{
+		leaf("a", "", true),
+		leaf("b", "", false),
+		leaf("c", "", false),
+	}}) {
+		t.Errorf("parseValidators incorrectly parses negation")
+	}
+
+	n, _ = parseValidators("!(a | b & !(c | d)) & e")
+	if n == nil || !reflect.DeepEqual(*n, node{op: '&', operands: []node{
+		leaf("a", "", true),
+		{op: '|', operands: []node{
+			leaf("b", "", true),
+			leaf("c", "", false),
+			leaf("d", "", false),
+		}},
+		leaf("e", "", false),
+	}}) {
+		t.Errorf("parseValidators incorrectly parses negation")
+	}
+
+	for validators, column := range map[string]int{
+		"(a=1 & b=2":   1,
+		"a & (b | (c)": 5,
+		"a=1) | b":     4,
+		"a & ()":       6,
+		"a ( b":        3,
+		"a=é | (b":     7,
+	} {
+		_, err := parseValidators(validators)
+		if e, ok := err.(ErrorSyntax); !ok || e.Column() != column {
+			t.Errorf("parseValidators does not report a column of an error in %v: %v", validators, err)
+		}
+	}
 }
 
 func TestParseTokens(t *testing.T) {
@@ -154,6 +198,12 @@ func TestParseTokens(t *testing.T) {
 	if !reflect.DeepEqual(tokens, res) {
 		t.Errorf("parseTokens incorrectly parses validators")
 	}
+
+	tokens = parseTokens(` 'a,b' , "x=y", ' c', '', 'it\'s', "\\", a'|'b `)
+	res = []interface{}{"a,b", "x=y", " c", "", "it's", "\\", "a|b"}
+	if !reflect.DeepEqual(tokens, res) {
+		t.Errorf("parseTokens incorrectly parses quoted tokens %q", tokens)
+	}
 }
 
 func TestBasic(t *testing.T) {
@@ -469,40 +519,1142 @@ func TestErrors(t *testing.T) {
 		t.Errorf("error of the wrong type")
 	}
 
-	err = Validate(struct {
-		field *int `validate:"gte=0"`
-	}{
-		field: &zero,
-	})
+	err = Validate(struct {
+		field *int `validate:"gte=0"`
+	}{
+		field: &zero,
+	})
+
+	switch err.(type) {
+	case ErrorSyntax:
+	default:
+		t.Errorf("error of the wrong type")
+	}
+
+	err = Validate(struct {
+		field *int `validate:"lte=0"`
+	}{
+		field: &zero,
+	})
+
+	switch err.(type) {
+	case ErrorSyntax:
+	default:
+		t.Errorf("error of the wrong type")
+	}
+
+	err = Validate(struct {
+		field *int `validate:"format=email"`
+	}{
+		field: &zero,
+	})
+
+	switch err.(type) {
+	case ErrorSyntax:
+	default:
+		t.Errorf("error of the wrong type")
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	var err error
+
+	err = ValidateAll(struct {
+		a int    `validate:"gte=0"`
+		b string `validate:"empty=false"`
+		c []int  `validate:"> gte=0"`
+	}{
+		a: 0,
+		b: " ",
+		c: []int{0, 1},
+	})
+
+	if err != nil {
+		t.Errorf("validate all does not validate")
+	}
+
+	err = ValidateAll(struct {
+		a int            `validate:"gte=0"`
+		b string         `validate:"empty=false"`
+		c []int          `validate:"> gte=0"`
+		d map[string]int `validate:"ne=0 [empty=false] > gte=0"`
+		e []int          `validate:"&&"`
+	}{
+		a: -1,
+		b: "",
+		c: []int{-1, 0, -1},
+		d: map[string]int{"b": -1, "a": -1, "": 0},
+	})
+
+	switch err.(type) {
+	case Errors:
+	default:
+		t.Errorf("error of the wrong type")
+	}
+
+	errs, _ := err.(Errors)
+	if len(errs) != 8 {
+		t.Errorf("validate all does not collect all errors")
+	}
+
+	for i, name := range []string{"a", "b", "c", "c", "d", "d", "d"} {
+		if i >= len(errs) {
+			break
+		}
+		if e, ok := errs[i].(ErrorValidation); !ok || e.FieldName() != name {
+			t.Errorf("validate all collects errors in the wrong order")
+		}
+	}
+
+	if len(errs) == 8 {
+		if _, ok := errs[7].(ErrorSyntax); !ok {
+			t.Errorf("error of the wrong type")
+		}
+		if e := errs[4].(ErrorValidation); e.fieldValue.String() != "" {
+			t.Errorf("validate all does not sort map keys")
+		}
+		if e := errs[5].(ErrorValidation); e.fieldValue.Int() != -1 {
+			t.Errorf("validate all does not sort map keys")
+		}
+	}
+
+	if nil == Validate(struct {
+		e []int `validate:"&&"`
+	}{}) {
+		t.Errorf("validate does not validate")
+	}
+
+	err = ValidateAll(struct {
+		e []int `validate:"&&"`
+	}{})
+
+	if errs, ok := err.(Errors); !ok || len(errs) != 1 {
+		t.Errorf("validate all does not collect syntax errors")
+	} else if _, ok := errs[0].(ErrorSyntax); !ok {
+		t.Errorf("error of the wrong type")
+	}
+}
+
+func TestFieldPath(t *testing.T) {
+	type Address struct {
+		Zip string `validate:"format=numeric"`
+	}
+
+	type User struct {
+		Addresses map[string]*Address
+	}
+
+	users := struct {
+		Users []User
+	}{
+		Users: []User{
+			User{},
+			User{
+				Addresses: map[string]*Address{
+					"home": &Address{Zip: "a"},
+				},
+			},
+		},
+	}
+
+	err := Validate(users)
+
+	e, ok := err.(ErrorValidation)
+	if !ok {
+		t.Fatalf("error of the wrong type")
+	}
+
+	if e.FieldName() != "Zip" {
+		t.Errorf("error has a wrong field name")
+	}
+
+	if e.FieldPath().String() != "Users[1].Addresses[home].Zip" {
+		t.Errorf("error has a wrong field path")
+	}
+
+	if !reflect.DeepEqual(e.FieldPath(), Path{
+		PathSegment{Type: PathSegmentField, Name: "Users"},
+		PathSegment{Type: PathSegmentIndex, Index: 1},
+		PathSegment{Type: PathSegmentField, Name: "Addresses"},
+		PathSegment{Type: PathSegmentKey, Key: "home"},
+		PathSegment{Type: PathSegmentPointer},
+		PathSegment{Type: PathSegmentField, Name: "Zip"},
+	}) {
+		t.Errorf("error has a wrong field path")
+	}
+
+	err = Validate(struct {
+		field map[int]int `validate:"[gte=0]"`
+	}{
+		field: map[int]int{-1: 0},
+	})
+
+	if e, ok := err.(ErrorValidation); !ok || e.FieldPath().String() != "field[-1]" {
+		t.Errorf("error has a wrong field path")
+	}
+
+	err = Validate([]int{1})
+	if err != nil {
+		t.Errorf("validate does not validate")
+	}
+
+	err = Validate(struct {
+		field []int `validate:"> eq=0s"`
+	}{
+		field: []int{1},
+	})
+
+	if e, ok := err.(ErrorSyntax); !ok || e.FieldPath().String() != "field[0]" || e.FieldName() != "field" {
+		t.Errorf("error has a wrong field path")
+	}
+}
+
+func TestFieldNameFunc(t *testing.T) {
+	type Address struct {
+		Zip string `json:"zip_code,omitempty" validate:"format=numeric"`
+	}
+
+	type User struct {
+		Addresses map[string]Address `json:"addresses"`
+		Name      string             `json:"-" validate:"empty=false"`
+		Nick      string             `json:",omitempty" validate:"empty=false"`
+	}
+
+	defer SetFieldNameFunc(nil)
+	SetFieldNameFunc(TagFieldName("json"))
+
+	err := Validate(User{
+		Addresses: map[string]Address{"home": Address{Zip: "a"}},
+	})
+	if e, ok := err.(ErrorValidation); !ok || e.FieldPath().String() != "addresses[home].zip_code" || e.FieldName() != "zip_code" {
+		t.Errorf("error has a wrong field path")
+	}
+
+	err = Validate(User{
+		Nick: "nick",
+	})
+	if e, ok := err.(ErrorValidation); !ok || e.FieldPath().String() != "Name" {
+		t.Errorf("error has a wrong field path")
+	}
+
+	err = Validate(User{
+		Name: "name",
+	})
+	if e, ok := err.(ErrorValidation); !ok || e.FieldPath().String() != "Nick" {
+		t.Errorf("error has a wrong field path")
+	}
+
+	SetFieldNameFunc(nil)
+
+	err = Validate(User{
+		Addresses: map[string]Address{"home": Address{Zip: "a"}},
+	})
+	if e, ok := err.(ErrorValidation); !ok || e.FieldPath().String() != "Addresses[home].Zip" {
+		t.Errorf("error has a wrong field path")
+	}
+}
+
+type StBase struct {
+	ID string `json:"id" validate:"empty=false"`
+}
+
+type StAudit struct {
+	By string `json:"by" validate:"empty=false"`
+}
+
+type StExtra struct {
+	Note string `json:"note" validate:"lte=3"`
+}
+
+func TestFieldNameFuncEmbedded(t *testing.T) {
+	type Account struct {
+		StBase
+		*StAudit
+		StExtra `json:"extra"`
+		Name    string `json:"name" validate:"empty=false"`
+	}
+
+	v := New(WithFieldNameFunc(TagFieldName("json")))
+
+	err := v.Validate(Account{StAudit: &StAudit{By: "admin"}, Name: "name"})
+	if e, ok := err.(ErrorValidation); !ok || e.FieldPath().String() != "id" || e.FieldName() != "id" {
+		t.Errorf("error has a wrong field path of an embedded struct, got %v", err)
+	}
+
+	err = v.Validate(Account{StBase: StBase{ID: "1"}, StAudit: &StAudit{}, Name: "name"})
+	if e, ok := err.(ErrorValidation); !ok || e.FieldPath().String() != "by" {
+		t.Errorf("error has a wrong field path of an embedded pointer, got %v", err)
+	}
+
+	err = v.Validate(Account{StBase: StBase{ID: "1"}, StAudit: &StAudit{By: "admin"}, StExtra: StExtra{Note: "long"}, Name: "name"})
+	if e, ok := err.(ErrorValidation); !ok || e.FieldPath().String() != "extra.note" {
+		t.Errorf("error has a wrong field path of a named embedded struct, got %v", err)
+	}
+
+	err = v.CheckType(reflect.TypeOf(struct {
+		StBase
+		Bad int `json:"bad" validate:"gte=x"`
+	}{}))
+	if errs, ok := err.(Errors); !ok || len(errs) != 1 || errs[0].(ErrorSyntax).FieldPath().String() != "bad" {
+		t.Errorf("error has a wrong field path when a type is checked, got %v", err)
+	}
+
+	err = New().Validate(Account{StAudit: &StAudit{By: "admin"}, Name: "name"})
+	if e, ok := err.(ErrorValidation); !ok || e.FieldPath().String() != "StBase.ID" {
+		t.Errorf("error has a wrong field path of an embedded struct with Go names, got %v", err)
+	}
+}
+
+func TestRegisterValidator(t *testing.T) {
+	// Register validators on a fresh default validator to run the test repeatedly
+	defer func(v *Validator) { defaultValidator = v }(defaultValidator)
+	defaultValidator = New()
+
+	even := func(value reflect.Value, validator string) error {
+		if value.Kind() != reflect.Int {
+			return ErrSyntax
+		}
+		if value.Int()%2 != 0 {
+			return errors.New("value should be even")
+		}
+		return nil
+	}
+
+	if nil != RegisterValidator("test_even", even) {
+		t.Errorf("register validator does not register a validator")
+	}
+
+	if nil == RegisterValidator("test_even", even) {
+		t.Errorf("register validator registers a validator twice")
+	}
+
+	if nil == RegisterValidator(ValidatorEq, even) {
+		t.Errorf("register validator overrides a built-in validator")
+	}
+
+	if nil == RegisterValidator("test even", even) {
+		t.Errorf("register validator registers an invalid validator type")
+	}
+
+	if nil == RegisterValidator("test_nil", nil) {
+		t.Errorf("register validator registers a nil validator")
+	}
+
+	if nil != Validate(struct {
+		field []int `validate:"> test_even"`
+	}{
+		field: []int{0, 2},
+	}) {
+		t.Errorf("custom validator does not validate")
+	}
+
+	err := Validate(struct {
+		field []int `validate:"> test_even"`
+	}{
+		field: []int{0, 1},
+	})
+
+	if e, ok := err.(ErrorValidation); !ok || e.FieldPath().String() != "field[1]" || e.Unwrap() == nil {
+		t.Errorf("custom validator does not validate")
+	}
+
+	err = Validate(struct {
+		field string `validate:"test_even"`
+	}{})
+
+	if _, ok := err.(ErrorSyntax); !ok {
+		t.Errorf("error of the wrong type")
+	}
+
+	if nil != OverrideValidator("test_even", func(value reflect.Value, validator string) error {
+		return nil
+	}) {
+		t.Errorf("override validator does not override a validator")
+	}
+
+	if nil != Validate(struct {
+		field []int `validate:"> test_even"`
+	}{
+		field: []int{0, 1},
+	}) {
+		t.Errorf("custom validator does not validate")
+	}
+
+	if nil != OverrideValidator(ValidatorEq, even) {
+		t.Errorf("override validator does not override a validator")
+	}
+
+	if nil == Validate(struct {
+		field int `validate:"eq=1"`
+	}{
+		field: 1,
+	}) {
+		t.Errorf("custom validator does not validate")
+	}
+}
+
+func TestNew(t *testing.T) {
+	type S struct {
+		A int    `validate:"gte=0" check:"lte=0" json:"a"`
+		B string `check:"format=test_upper"`
+	}
+
+	a := New()
+	b := New(WithTag("check"), WithFieldNameFunc(TagFieldName("json")), WithAllErrors())
+
+	if nil != b.RegisterFormat("test_upper", func(value string) bool {
+		return strings.ToUpper(value) == value
+	}) {
+		t.Errorf("register format does not register a format")
+	}
+
+	if nil != a.Validate(S{A: 1, B: "b"}) {
+		t.Errorf("validator does not validate")
+	}
+
+	if nil == a.Validate(S{A: -1, B: "B"}) {
+		t.Errorf("validator does not validate")
+	}
+
+	if nil != b.Validate(S{A: -1, B: "B"}) {
+		t.Errorf("validator does not use its tag")
+	}
+
+	err := b.Validate(S{A: 1, B: "b"})
+	if errs, ok := err.(Errors); !ok || len(errs) != 2 {
+		t.Errorf("validator does not collect all errors")
+	} else if e, ok := errs[0].(ErrorValidation); !ok || e.FieldPath().String() != "a" {
+		t.Errorf("validator does not use its field name function")
+	}
+
+	if nil != b.CheckTag(reflect.TypeOf(""), "format=test_upper") {
+		t.Errorf("register format does not register a format")
+	}
+
+	if nil == a.CheckTag(reflect.TypeOf(""), "format=test_upper") {
+		t.Errorf("validators share formats")
+	}
+
+	if nil == CheckTag(reflect.TypeOf(""), "format=test_upper") {
+		t.Errorf("validators share formats")
+	}
+
+	if nil != a.RegisterValidator("test_a", func(value reflect.Value, validator string) error {
+		return nil
+	}) {
+		t.Errorf("register validator does not register a validator")
+	}
+
+	if nil != a.CheckTag(reflect.TypeOf(0), "test_a=1") {
+		t.Errorf("register validator does not register a validator")
+	}
+
+	if nil == b.CheckTag(reflect.TypeOf(0), "test_a=1") {
+		t.Errorf("validators share validators")
+	}
+}
+
+func TestStringLength(t *testing.T) {
+	cases := []struct {
+		value     string
+		bytes     int
+		runes     int
+		graphemes int
+	}{
+		{"", 0, 0, 0},
+		{"abc", 3, 3, 3},
+		{"Привіт", 12, 6, 6},
+		{"e\u0301", 3, 2, 1},
+		{"\u0301e", 3, 2, 2},
+		{"a\r\nb", 4, 4, 3},
+		{"\U0001F1FA\U0001F1E6\U0001F1F5", 12, 3, 2},
+		{"\U0001F44D\U0001F3FD", 8, 2, 1},
+		{"\U0001F468\u200d\U0001F469\u200d\U0001F467", 18, 5, 1},
+		{"\u2764\ufe0f!", 7, 3, 2},
+	}
+
+	for _, c := range cases {
+		if n := StringLengthBytes.count(c.value); n != c.bytes {
+			t.Errorf("string length counts %v bytes of %q instead of %v", n, c.value, c.bytes)
+		}
+		if n := StringLengthRunes.count(c.value); n != c.runes {
+			t.Errorf("string length counts %v runes of %q instead of %v", n, c.value, c.runes)
+		}
+		if n := StringLengthGraphemes.count(c.value); n != c.graphemes {
+			t.Errorf("string length counts %v graphemes of %q instead of %v", n, c.value, c.graphemes)
+		}
+	}
+
+	type S struct {
+		Name  string `validate:"lte=6"`
+		Limit int
+		Alias string `validate:"lte_field=Limit"`
+	}
+
+	name := S{Name: "Привіт"}
+	alias := S{Alias: "Привіт", Limit: 6}
+
+	if nil == Validate(name) || nil == Validate(alias) {
+		t.Errorf("validator does not count bytes by default")
+	}
+
+	v := New(WithStringLength(StringLengthRunes))
+	if nil != v.Validate(name) || nil != v.Validate(alias) {
+		t.Errorf("validator does not count runes")
+	}
+
+	v = New(WithStringLength(StringLengthGraphemes))
+	if nil != v.Validate(S{Name: "e\u0301e\u0301e\u0301e\u0301"}) {
+		t.Errorf("validator does not count graphemes")
+	}
+
+	defer SetStringLength(StringLengthBytes)
+
+	SetStringLength(StringLengthRunes)
+
+	if nil != Validate(name) {
+		t.Errorf("validator does not use its unit of a string length")
+	}
+
+	SetStringLength(StringLengthBytes)
+
+	if nil == Validate(name) {
+		t.Errorf("validator does not restore its unit of a string length")
+	}
+
+	v = New()
+	done := make(chan bool)
+	go func() {
+		for j := 0; j < 100; j++ {
+			v.SetStringLength(StringLength(j % 2))
+		}
+		done <- true
+	}()
+	for j := 0; j < 100; j++ {
+		if err := v.Validate(S{Name: "abc"}); err != nil {
+			t.Errorf("validator does not validate concurrently: %v", err)
+		}
+	}
+	<-done
+}
+
+func TestSetTag(t *testing.T) {
+	type S struct {
+		A string `validate:"required,min=1" vd:"empty=false"`
+	}
+
+	defer SetTag("")
+
+	if nil == Validate(S{A: "a"}) {
+		t.Errorf("validator does not use the validate tag")
+	}
+
+	SetTag("vd")
+
+	if nil != Validate(S{A: "a"}) {
+		t.Errorf("validator does not use its tag")
+	}
+
+	if nil == Validate(S{A: ""}) {
+		t.Errorf("validator does not use its tag")
+	}
+
+	SetTag("")
+
+	if nil == Validate(S{A: "a"}) {
+		t.Errorf("validator does not restore the validate tag")
+	}
+
+	v := New(WithTag("vd"))
+
+	if nil != v.Validate(S{A: "a"}) {
+		t.Errorf("validator does not use its tag")
+	}
+
+	v.SetTag("")
+
+	if nil == v.Validate(S{A: "a"}) {
+		t.Errorf("validator does not restore the validate tag")
+	}
+}
+
+type StRecursive struct {
+	Value int            `validate:"gte=0"`
+	Next  *StRecursive   `validate:"nil=true | nil=false"`
+	Items []*StRecursive `validate:"> nil=false"`
+}
+
+func TestPlanCache(t *testing.T) {
+	v := New()
+	typ := reflect.TypeOf(StRecursive{})
+
+	p := v.getPlan(typ, "", nil)
+	if p != v.getPlan(typ, "", nil) {
+		t.Errorf("validator does not cache plans")
+	}
+
+	if p.fields.fields[1].plan.elem.fields != p.fields {
+		t.Errorf("validator does not cache plans of recursive types")
+	}
+
+	if nil != v.Validate(StRecursive{
+		Next:  &StRecursive{Value: 1},
+		Items: []*StRecursive{&StRecursive{}},
+	}) {
+		t.Errorf("validator does not validate recursive types")
+	}
+
+	err := v.Validate(StRecursive{
+		Next: &StRecursive{Next: &StRecursive{Value: -1}},
+	})
+	if e, ok := err.(ErrorValidation); !ok || e.FieldPath().String() != "Next.Next.Value" {
+		t.Errorf("validator does not validate recursive types")
+	}
+
+	if nil != v.RegisterFormat("test_cache", formatAlpha) {
+		t.Errorf("register format does not register a format")
+	}
+
+	if p == v.getPlan(typ, "", nil) {
+		t.Errorf("validator does not drop cached plans")
+	}
+
+	done := make(chan bool)
+	for i := 0; i < 8; i++ {
+		go func() {
+			for j := 0; j < 100; j++ {
+				if nil == v.Validate([]StRecursive{StRecursive{Value: -1}}) {
+					t.Errorf("validator does not validate concurrently")
+				}
+			}
+			done <- true
+		}()
+	}
+	for i := 0; i < 8; i++ {
+		<-done
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	type Item struct {
+		Name  string            `validate:"gte=1 & lte=25 & format=alnum"`
+		Count int               `validate:"gte=0 & lte=100"`
+		Tags  map[string]string `validate:"lte=5 [empty=false] > one_of=a,b,c"`
+	}
+
+	items := make([]Item, 1000)
+	for i := range items {
+		items[i] = Item{Name: "item", Count: i % 100, Tags: map[string]string{"k": "a"}}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := Validate(items); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestCheckType(t *testing.T) {
+	type Address struct {
+		Zip   string  `validate:"format=zip_code"`
+		Score float64 `validate:"format=email"`
+	}
+
+	type User struct {
+		Age       int                 `validate:"nil=false"`
+		Addresses map[string]*Address `validate:"ne=0 [empty=false] > nil=false"`
+		Tags      []string            `validate:"> gte=a"`
+		Next      *User
+	}
+
+	if nil != CheckType(reflect.TypeOf(StRecursive{})) {
+		t.Errorf("check type reports errors for a valid type")
+	}
+
+	err := CheckType(reflect.TypeOf([]User{}))
+
+	errs, ok := err.(Errors)
+	if !ok || len(errs) != 4 {
+		t.Fatalf("check type does not report all errors")
+	}
+
+	for i, path := range []string{"[*].Age", "[*].Addresses[*].Zip", "[*].Addresses[*].Score", "[*].Tags[*]"} {
+		if e, ok := errs[i].(ErrorSyntax); !ok || e.FieldPath().String() != path {
+			t.Errorf("check type reports a wrong error")
+		}
+	}
+
+	if nil != Validate(struct {
+		Tags []string `validate:"> gte=a"`
+	}{}) {
+		t.Errorf("validator validates a value which is not reached")
+	}
+
+	if nil == CheckType(reflect.TypeOf(struct {
+		Tags []string `validate:"> gte=a"`
+	}{})) {
+		t.Errorf("check type does not check a value which is not reached")
+	}
+
+	if nil != CheckType(nil) {
+		t.Errorf("check type reports errors for a nil type")
+	}
+
+	MustCompile(nil)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("must compile does not panic")
+		}
+	}()
+
+	MustCompile(User{})
+}
+
+func TestCheckTag(t *testing.T) {
+	if nil != CheckTag(reflect.TypeOf(map[string][]int{}), "gte=1 [format=alpha] > empty=false > gte=0") {
+		t.Errorf("check tag reports errors for a valid tag")
+	}
+
+	if nil == CheckTag(reflect.TypeOf(0), "format=alpha") {
+		t.Errorf("check tag does not check a kind of a field")
+	}
+
+	if nil != CheckTag(nil, "gte=1 [format=alpha] > empty=false > gte=0") {
+		t.Errorf("check tag reports errors for a valid tag")
+	}
+
+	err := CheckTag(nil, "gte=1 & foo=1 [format=unknown] > empty=false > gte=0 | bar")
+	if errs, ok := err.(Errors); !ok || len(errs) != 3 {
+		t.Errorf("check tag does not report all errors")
+	} else if errs[1].(ErrorSyntax).FieldPath().String() != "[*]" {
+		t.Errorf("check tag reports a wrong error")
+	}
+
+	if nil == CheckTag(nil, "gte=1 [format=alpha") {
+		t.Errorf("check tag does not check syntax")
+	}
+}
+
+func TestCheckFieldTag(t *testing.T) {
+	type Inner struct {
+		Max int
+	}
+
+	parent := reflect.TypeOf(struct {
+		A     int
+		B     int
+		Kind  string
+		Inner *Inner
+	}{})
+
+	if nil != CheckFieldTag(reflect.TypeOf(0), "gt_field=B & lte_field=Inner.Max & required_if=Kind:x", parent) {
+		t.Errorf("check field tag reports errors for a valid tag")
+	}
+
+	for _, validators := range []string{"gt_field=Missing", "lte_field=Inner.Min", "required_if=Nope:x", "required_with=Kind,Nope", "if=Zzz:1 ? gte=1"} {
+		if nil == CheckFieldTag(reflect.TypeOf(0), validators, parent) {
+			t.Errorf("check field tag does not report a missing field for %v", validators)
+		}
+		if nil != CheckTag(reflect.TypeOf(0), validators) {
+			t.Errorf("check tag reports a missing field for %v without a parent", validators)
+		}
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	tag, err := ParseTag("gte=1 & lte=2 | eq=4 [nil=false > empty=false] > one_of=a,b")
+	if err != nil {
+		t.Fatalf("parse tag returns an error for a valid tag: %v", err)
+	}
+
+	expected := &Tag{
+		Validators: &TagExpression{Operator: '|', Operands: []*TagExpression{
+			{Operator: '&', Operands: []*TagExpression{
+				{Validator: TagValidator{ValidatorGte, "1", false}},
+				{Validator: TagValidator{ValidatorLte, "2", false}},
+			}},
+			{Validator: TagValidator{ValidatorEq, "4", false}},
+		}},
+		Key: &Tag{
+			Validators: &TagExpression{Validator: TagValidator{ValidatorNil, "false", false}},
+			Elem: &Tag{
+				Validators: &TagExpression{Validator: TagValidator{ValidatorEmpty, "false", false}},
+			},
+		},
+		Elem: &Tag{
+			Validators: &TagExpression{Validator: TagValidator{ValidatorOneOf, "a,b", false}},
+		},
+	}
+
+	if !reflect.DeepEqual(tag, expected) {
+		t.Errorf("parse tag returns a wrong tag %+v", tag)
+	}
+
+	tag, err = ParseTag("if=Method:card & with=Number ? gte=1")
+	if err != nil {
+		t.Fatalf("parse tag returns an error for a valid tag: %v", err)
+	}
+
+	expected = &Tag{
+		Condition: &TagExpression{Operator: '&', Operands: []*TagExpression{
+			{Validator: TagValidator{"if", "Method:card", false}},
+			{Validator: TagValidator{"with", "Number", false}},
+		}},
+		Validators: &TagExpression{Validator: TagValidator{ValidatorGte, "1", false}},
+	}
+
+	if !reflect.DeepEqual(tag, expected) {
+		t.Errorf("parse tag returns a wrong tag %+v", tag)
+	}
+
+	if _, err := ParseTag("gte=1 [nil=false"); err == nil {
+		t.Errorf("parse tag does not check syntax")
+	}
+
+	if _, err := ParseTag("when=Method:card ? gte=1"); err == nil {
+		t.Errorf("parse tag does not check conditions")
+	}
+}
+
+type StGenerated struct {
+	field int `validate:"gte=0"`
+}
+
+func (st StGenerated) Validate() error {
+	return errors.New("generated validate should not be called")
+}
+
+func (st StGenerated) ValidateCustom() error {
+	return nil
+}
+
+func (st StGenerated) ValidateFields(s *State) error {
+	s.PushField("field", false)
+	if !(int64(st.field) >= 0) {
+		if err := s.Fail(st.field, "gte", "0"); err != nil {
+			return err
+		}
+	}
+	s.Pop()
+	return nil
+}
+
+func TestGeneratedValidator(t *testing.T) {
+	if nil != Validate(StGenerated{}) {
+		t.Errorf("generated validate method is called as a custom validator")
+	}
+
+	if nil != Validate(struct{ P *StGenerated }{}) {
+		t.Errorf("generated methods are called for a nil pointer")
+	}
+
+	if nil != CrossCheck(StGenerated{field: -1}) {
+		t.Errorf("cross check reports a difference of the same results")
+	}
+
+	if nil == CrossCheck(stGeneratedBroken{}) {
+		t.Errorf("cross check does not report a difference of results")
+	}
+}
+
+type stGeneratedBroken struct {
+	StGenerated
+}
+
+func (st stGeneratedBroken) ValidateFields(s *State) error {
+	return s.Fail(0, "gte", "0")
+}
+
+func TestFieldVals(t *testing.T) {
+	type Limits struct {
+		Max int
+	}
+
+	type S struct {
+		Password        string `validate:"empty=false"`
+		ConfirmPassword string `validate:"eq_field=Password"`
+		OldPassword     string `validate:"ne_field=Password"`
+		Start           time.Duration
+		End             time.Duration `validate:"gt_field=Start"`
+		Min             uint8
+		Count           int      `validate:"gte_field=Min & lte_field=Limits.Max"`
+		Tags            []string `validate:"lte_field=Count"`
+		Limits          *Limits
+	}
+
+	valid := S{
+		Password:        "secret",
+		ConfirmPassword: "secret",
+		OldPassword:     "old",
+		Start:           time.Second,
+		End:             time.Minute,
+		Min:             1,
+		Count:           2,
+		Tags:            []string{"a", "b"},
+		Limits:          &Limits{Max: 5},
+	}
+
+	if nil != Validate(valid) {
+		t.Errorf("field validators do not validate")
+	}
+
+	for _, invalid := range []func(s *S){
+		func(s *S) { s.ConfirmPassword = "secret2" },
+		func(s *S) { s.OldPassword = "secret" },
+		func(s *S) { s.End = s.Start },
+		func(s *S) { s.Count = 0 },
+		func(s *S) { s.Count = 6 },
+		func(s *S) { s.Tags = []string{"a", "b", "c"} },
+		func(s *S) { s.Limits = nil },
+	} {
+		s := valid
+		invalid(&s)
+		if _, ok := Validate(s).(ErrorValidation); !ok {
+			t.Errorf("field validators do not validate")
+		}
+	}
+
+	if e, ok := Validate(S{Limits: &Limits{}}).(ErrorValidation); !ok || e.FieldPath().String() != "Password" {
+		t.Errorf("field validators do not validate")
+	}
+
+	if nil == Validate(struct {
+		field int8 `validate:"gt_field=min"`
+		min   uint64
+	}{
+		field: -1,
+		min:   0,
+	}) {
+		t.Errorf("field validators do not compare signed and unsigned numbers")
+	}
+
+	if nil != Validate(struct {
+		field float32 `validate:"gt_field=min"`
+		min   int
+	}{
+		field: 1.5,
+		min:   1,
+	}) {
+		t.Errorf("field validators do not compare floats and integers")
+	}
+
+	type Period struct {
+		Start time.Time
+		End   *time.Time `validate:"gt_field=Start"`
+	}
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	if err := Validate(Period{Start: start, End: &end}); err != nil {
+		t.Errorf("field validators do not dereference a validated pointer: %v", err)
+	}
+
+	for _, p := range []Period{{Start: start, End: &start}, {Start: start}} {
+		if _, ok := Validate(p).(ErrorValidation); !ok {
+			t.Errorf("field validators do not validate a pointer")
+		}
+	}
+
+	if nil != CheckTag(reflect.TypeOf(0), "eq_field=missing") {
+		t.Errorf("check tag reports an error for an unknown parent")
+	}
+
+	for _, validators := range []string{"eq_field=", "eq_field=a..b", "eq_field=a b"} {
+		if _, ok := CheckTag(reflect.TypeOf(0), validators).(Errors); !ok {
+			t.Errorf("check tag does not report an invalid path to a field")
+		}
+	}
+
+	err := CheckType(reflect.TypeOf(struct {
+		a    string `validate:"eq_field=missing"`
+		b    string `validate:"gt_field=flag"`
+		c    int    `validate:"eq_field=flag"`
+		d    string `validate:"eq_field=a.b"`
+		flag bool
+	}{}))
+	if errs, ok := err.(Errors); !ok || len(errs) != 4 {
+		t.Errorf("check type does not report field validators which could not be run")
+	}
+}
+
+func TestConditionalVals(t *testing.T) {
+	type Address struct {
+		Street string
+		City   string `validate:"required_with=Street"`
+	}
+
+	type S struct {
+		PaymentMethod string
+		CardNumber    string `validate:"required_if=PaymentMethod:card,debit"`
+		Change        *int   `validate:"excluded_if=PaymentMethod:card,debit"`
+		Country       string
+		State         string `validate:"required_unless=Country:UA"`
+		Email         string
+		Phone         string   `validate:"required_without=Email"`
+		Gift          bool     `validate:"required_if=Address.City:Kyiv"`
+		Wrap          bool     `validate:"required_if=Gift:true"`
+		Tags          []string `validate:"if=PaymentMethod:card ? gte=1 & lte=2 | eq=4"`
+		Address       *Address
+	}
+
+	change := 1
+
+	valid := S{
+		PaymentMethod: "card",
+		CardNumber:    "4242",
+		Country:       "UA",
+		Email:         "user@example.com",
+		Tags:          []string{"a"},
+		Address:       &Address{},
+	}
+
+	if nil != Validate(valid) {
+		t.Errorf("conditional validators do not validate")
+	}
+
+	for _, mutation := range []func(s *S){
+		func(s *S) { s.PaymentMethod, s.CardNumber, s.Tags = "cash", "", nil },
+		func(s *S) { s.PaymentMethod, s.Change = "cash", &change },
+		func(s *S) { s.Country, s.State = "US", "CA" },
+		func(s *S) { s.Email, s.Phone = "", "123" },
+		func(s *S) { s.Gift, s.Wrap = true, true },
+		func(s *S) { s.Address = &Address{City: "Kyiv"}; s.Gift, s.Wrap = true, true },
+		func(s *S) { s.Tags = []string{"a", "b", "c", "d"} },
+		func(s *S) { s.Address = nil },
+	} {
+		s := valid
+		mutation(&s)
+		if err := Validate(s); err != nil {
+			t.Errorf("conditional validators do not validate: %v", err)
+		}
+	}
+
+	for _, mutation := range []func(s *S){
+		func(s *S) { s.CardNumber = "" },
+		func(s *S) { s.PaymentMethod = "debit"; s.CardNumber = "" },
+		func(s *S) { s.Change = &change },
+		func(s *S) { s.Country = "US" },
+		func(s *S) { s.Email = "" },
+		func(s *S) { s.Address = &Address{City: "Kyiv"} },
+		func(s *S) { s.Gift = true },
+		func(s *S) { s.Address.Street = "Khreshchatyk" },
+		func(s *S) { s.Tags = nil },
+		func(s *S) { s.Tags = []string{"a", "b", "c"} },
+	} {
+		s := valid
+		address := *valid.Address
+		s.Address = &address
+		mutation(&s)
+		if _, ok := Validate(s).(ErrorValidation); !ok {
+			t.Errorf("conditional validators do not validate")
+		}
+	}
+
+	if e, ok := Validate(S{PaymentMethod: "card"}).(ErrorValidation); !ok || e.FieldPath().String() != "CardNumber" {
+		t.Errorf("conditional validators do not validate")
+	}
+
+	if nil != CheckTag(reflect.TypeOf(""), "if=Missing:a ? required_if=Missing:a & empty=false") {
+		t.Errorf("check tag reports an error for an unknown parent")
+	}
+
+	for _, validators := range []string{"required_if=Method", "required_if=:card", "required_with=", "if=Method ? gte=1"} {
+		if _, ok := CheckTag(reflect.TypeOf(""), validators).(Errors); !ok {
+			t.Errorf("check tag does not report an invalid condition %v", validators)
+		}
+	}
+
+	for _, validators := range []string{"when=Method:card ? gte=1", "? gte=1"} {
+		if _, ok := CheckTag(nil, validators).(Errors); !ok {
+			t.Errorf("check tag does not report an invalid guard %v", validators)
+		}
+	}
+
+	err := CheckType(reflect.TypeOf(struct {
+		a string `validate:"required_if=missing:a"`
+		b string `validate:"required_if=c:a"`
+		c bool   `validate:"required_with=a,missing"`
+		d string `validate:"unless=c:true ? gte=1"`
+		e string `validate:"if=c:maybe ? gte=1"`
+	}{}))
+	if errs, ok := err.(Errors); !ok || len(errs) != 4 {
+		t.Errorf("check type does not report conditions which could not be run: %v", err)
+	}
+}
+
+type stContextKey struct{}
+
+type StContextValidator struct {
+	field int
+}
+
+func (st StContextValidator) Validate() error {
+	return errors.New("validate should not be called if validate context is implemented")
+}
+
+func (st StContextValidator) ValidateContext(ctx context.Context) error {
+	if limit, ok := ctx.Value(stContextKey{}).(int); ok && st.field > limit {
+		return errors.New("field is greater than limit")
+	}
+	return nil
+}
+
+type StContextValidator2 struct {
+	cancel func()
+	calls  *int
+}
+
+func (st *StContextValidator2) ValidateContext(ctx context.Context) error {
+	*st.calls++
+	st.cancel()
+	return nil
+}
+
+func TestContextValidator(t *testing.T) {
+	ctx := context.WithValue(context.Background(), stContextKey{}, 5)
+
+	if nil != Validate(StContextValidator{field: 10}) {
+		t.Errorf("context validator does not use a background context")
+	}
+
+	if nil == ValidateContext(ctx, StContextValidator{field: 10}) {
+		t.Errorf("context validator does not use a given context")
+	}
+
+	if nil != ValidateContext(ctx, StContextValidator{field: 1}) {
+		t.Errorf("context validator does not use a given context")
+	}
+
+	if nil == ValidateContext(ctx, struct {
+		Field *StContextValidator
+	}{&StContextValidator{field: 10}}) {
+		t.Errorf("context validator is not called for a nested struct")
+	}
+
+	if errs, ok := ValidateAllContext(ctx, []StContextValidator{{field: 6}, {field: 1}, {field: 7}}).(Errors); !ok || len(errs) != 2 {
+		t.Errorf("context validator does not collect all errors")
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
 
-	switch err.(type) {
-	case ErrorSyntax:
-	default:
-		t.Errorf("error of the wrong type")
+	if err := ValidateContext(canceled, StContextValidator{}); err != context.Canceled {
+		t.Errorf("validate context does not return an error of a canceled context")
 	}
 
-	err = Validate(struct {
-		field *int `validate:"lte=0"`
-	}{
-		field: &zero,
-	})
+	canceled, cancel = context.WithCancel(context.Background())
+	defer cancel()
 
-	switch err.(type) {
-	case ErrorSyntax:
-	default:
-		t.Errorf("error of the wrong type")
+	calls := 0
+	elements := make([]StContextValidator2, 5)
+	for i := range elements {
+		elements[i] = StContextValidator2{cancel: cancel, calls: &calls}
 	}
 
-	err = Validate(struct {
-		field *int `validate:"format=email"`
-	}{
-		field: &zero,
-	})
-
-	switch err.(type) {
-	case ErrorSyntax:
-	default:
-		t.Errorf("error of the wrong type")
+	if err := ValidateAllContext(canceled, elements); err != context.Canceled || calls != 1 {
+		t.Errorf("validate context does not stop when a context is canceled")
 	}
 }
 
@@ -964,6 +2116,176 @@ func TestAndOrVal(t *testing.T) {
 	}
 }
 
+func TestParenthesesVal(t *testing.T) {
+	for value, valid := range map[int]bool{0: false, 1: true, 3: false, 5: true, 6: false, 10: true} {
+		err := Validate(struct {
+			field int `validate:"(gte=1 & lte=5 | eq=10) & ne=3"`
+		}{
+			field: value,
+		})
+		if (err == nil) != valid {
+			t.Errorf("parentheses does not validate %v", value)
+		}
+	}
+
+	if _, ok := Validate(struct {
+		field int `validate:"(gte=1 & lte=5"`
+	}{}).(ErrorSyntax); !ok {
+		t.Errorf("unbalanced parentheses does not return a syntax error")
+	}
+
+	for value, validator := range map[string]string{"abc": "\"ne=3\"", "abcdef": "\"eq=10\"", "": "\"eq=10\""} {
+		err := Validate(struct {
+			field string `validate:"(gte=1 & lte=5 | eq=10) & ne=3"`
+		}{
+			field: value,
+		})
+		if e, ok := err.(ErrorValidation); !ok || !strings.Contains(e.Error(), validator) {
+			t.Errorf("parentheses do not report validator %v deciding the result for %q: %v", validator, value, err)
+		}
+	}
+
+	err := Validate(struct {
+		field int `validate:"!(gte=1 & lte=5) & ne=10"`
+	}{
+		field: 3,
+	})
+	if e, ok := err.(ErrorValidation); !ok || !e.Negated() || !strings.Contains(e.Error(), "\"!lte=5\"") {
+		t.Errorf("negated parentheses do not report validator deciding the result: %v", err)
+	}
+
+	err = Validate(struct {
+		field []int `validate:"> (gte=0 | lte=100) & (gte=0 | lte=100) & (gte=0 | lte=100) & (gte=0 | lte=100) & (gte=0 | lte=100) & (gte=0 | lte=100) & (gte=0 | lte=100) & (gte=0 | lte=100) & (gte=0 | lte=100) & (gte=0 | lte=100) & (gte=0 | lte=100) & (gte=0 | lte=100) & (gte=0 | lte=100) & (gte=0 | lte=100) & (gte=0 | lte=100) & (gte=0 | lte=100) & ne=50"`
+	}{
+		field: []int{-1, 50},
+	})
+	if e, ok := err.(ErrorValidation); !ok || e.FieldPath().String() != "field[1]" || !strings.Contains(e.Error(), "\"ne=50\"") {
+		t.Errorf("parentheses do not validate a long expression: %v", err)
+	}
+}
+
+func TestNegationVal(t *testing.T) {
+	err := Validate(struct {
+		field string `validate:"!one_of=root,admin"`
+	}{
+		field: "root",
+	})
+	if e, ok := err.(ErrorValidation); !ok || !e.Negated() || !strings.Contains(e.Error(), "\"!one_of=root,admin\"") {
+		t.Errorf("negated validator does not validate: %v", err)
+	}
+
+	if nil != Validate(struct {
+		field string `validate:"!one_of=root,admin"`
+	}{
+		field: "user",
+	}) {
+		t.Errorf("negated validator does not validate")
+	}
+
+	for value, valid := range map[int]bool{0: true, 1: false, 5: false, 6: true, 10: false} {
+		err := Validate(struct {
+			field int `validate:"!(gte=1 & lte=5) & !!ne=10"`
+		}{
+			field: value,
+		})
+		if (err == nil) != valid {
+			t.Errorf("negated expression does not validate %v", value)
+		}
+	}
+
+	if _, ok := Validate(struct {
+		field int `validate:"!gte=abc"`
+	}{}).(ErrorSyntax); !ok {
+		t.Errorf("negated validator does not return a syntax error")
+	}
+
+	if e, ok := Validate(struct {
+		field int `validate:"!gte=0"`
+	}{}).(ErrorValidation); !ok || !e.Negated() {
+		t.Errorf("negated validator does not validate")
+	}
+
+	if e, ok := Validate(struct {
+		field int `validate:"gte=1"`
+	}{}).(ErrorValidation); !ok || e.Negated() {
+		t.Errorf("validator is reported as negated")
+	}
+
+	type S struct {
+		Method string
+		Card   string `validate:"!if=Method:cash ? empty=false"`
+	}
+
+	if nil != Validate(S{Method: "cash"}) || nil == Validate(S{Method: "card"}) {
+		t.Errorf("negated condition does not validate")
+	}
+}
+
+func TestQuotedVal(t *testing.T) {
+	type S struct {
+		Field string `validate:"one_of='a,b',\"x=y\",' c','>','[','it\\'s' & !one_of='|','&'"`
+	}
+
+	for value, valid := range map[string]bool{"a,b": true, "x=y": true, " c": true, ">": true, "[": true, "it's": true, "a": false, "c": false, "|": false} {
+		if err := Validate(S{value}); (err == nil) != valid {
+			t.Errorf("quoted values do not validate %q: %v", value, err)
+		}
+	}
+
+	if nil != Validate(struct {
+		Field map[string]int `validate:"[one_of='[key]','a>b'] > one_of=1"`
+	}{
+		Field: map[string]int{"[key]": 1, "a>b": 1},
+	}) {
+		t.Errorf("quoted values do not validate map keys")
+	}
+
+	type T struct {
+		Method string
+		Card   string `validate:"if=Method:'card?',\"debit\" ? empty=false"`
+	}
+
+	if nil == Validate(T{Method: "card?"}) || nil != Validate(T{Method: "card"}) {
+		t.Errorf("quoted values do not validate conditions")
+	}
+
+	if _, ok := Validate(struct {
+		Field string `validate:"one_of='a,b"`
+	}{}).(ErrorSyntax); !ok {
+		t.Errorf("unclosed quote does not return a syntax error")
+	}
+
+	type U struct {
+		Email string `validate:"format='email'"`
+		Count int    `validate:"gte='10'"`
+	}
+
+	if err := Validate(U{Email: "user@example.com", Count: 10}); err != nil {
+		t.Errorf("quoted values are not unquoted: %v", err)
+	}
+
+	err := Validate(U{Email: "user@example.com"})
+	if e, ok := err.(ErrorValidation); !ok || !strings.Contains(e.Error(), `"gte='10'"`) {
+		t.Errorf("error does not report a quoted value, got %v", err)
+	}
+
+	if nil != CheckTag(nil, "format='email'") {
+		t.Errorf("check tag does not unquote a format")
+	}
+
+	v := New()
+	var quoted string
+	v.RegisterValidator("test_value", func(value reflect.Value, validator string) error {
+		quoted = validator
+		return nil
+	})
+	if err := v.Validate(struct {
+		Field int `validate:"test_value='a b'"`
+	}{}); err != nil || quoted != "a b" {
+		t.Errorf("custom validator gets a quoted value %q", quoted)
+	}
+}
+
 func TestFormatVal(t *testing.T) {
 	if nil == Validate(struct {
 		field int `validate:" gte = 0 & lte = 10 & bla= "`
@@ -4056,6 +5378,507 @@ func TestFormatValForString(t *testing.T) {
 	}
 }
 
+func TestRegexpValForString(t *testing.T) {
+	if nil == Validate(struct {
+		field string `validate:"regexp='^[a-z]+(-[a-z]+)*$'"`
+	}{
+		field: "abc-",
+	}) {
+		t.Errorf("regexp validator does not validate for string")
+	}
+
+	if nil != Validate(struct {
+		field string `validate:"regexp='^[a-z]+(-[a-z]+)*$'"`
+	}{
+		field: "abc-def",
+	}) {
+		t.Errorf("regexp validator does not validate for string")
+	}
+
+	if nil != Validate(struct {
+		field string `validate:"regexp='^[0-9]+$' | empty=true"`
+	}{
+		field: "",
+	}) {
+		t.Errorf("regexp validator does not validate for string")
+	}
+
+	if nil == Validate(struct {
+		field []string `validate:"> regexp='^(a|b)$'"`
+	}{
+		field: []string{"a", "c"},
+	}) {
+		t.Errorf("regexp validator does not validate for string elements")
+	}
+
+	if nil != Validate(struct {
+		field []string `validate:"> regexp='^(a|b)$'"`
+	}{
+		field: []string{"a", "b"},
+	}) {
+		t.Errorf("regexp validator does not validate for string elements")
+	}
+
+	err := CheckType(reflect.TypeOf(struct {
+		field string `validate:"regexp='(a'"`
+	}{}))
+	if errs, ok := err.(Errors); !ok || len(errs) != 1 {
+		t.Errorf("regexp validator does not report an invalid regexp")
+	} else if _, ok := errs[0].(ErrorSyntax); !ok {
+		t.Errorf("regexp validator reports a wrong error")
+	}
+
+	if nil == CheckType(reflect.TypeOf(struct {
+		field int `validate:"regexp=^a$"`
+	}{})) {
+		t.Errorf("regexp validator does not check a kind of a field")
+	}
+
+	if nil == CheckTag(nil, "regexp=") {
+		t.Errorf("regexp validator does not report an empty regexp")
+	}
+
+	if nil == CheckTag(nil, "regexp='[a'") {
+		t.Errorf("regexp validator does not report an invalid regexp")
+	}
+}
+
+// validateTag validates a value as a field of a struct with a given tag
+func validateTag(value interface{}, validators string) error {
+	typ := reflect.StructOf([]reflect.StructField{{
+		Name: "Field",
+		Type: reflect.TypeOf(value),
+		Tag:  reflect.StructTag(`validate:"` + validators + `"`),
+	}})
+
+	element := reflect.New(typ).Elem()
+	element.Field(0).Set(reflect.ValueOf(value))
+
+	return Validate(element.Interface())
+}
+
+func TestStringValsForString(t *testing.T) {
+	cases := []struct {
+		tag   string
+		value string
+		valid bool
+	}{
+		{"is=v1", "v1", true},
+		{"is=v1", "v2", false},
+		{"is=V1", "v1", false},
+		{"is=", "", true},
+		{"is='a b'", "a b", true},
+		{"is_not=root", "root", false},
+		{"is_not=root", "user", true},
+		{"before=m", "abc", true},
+		{"before=m", "m", false},
+		{"before=m", "z", false},
+		{"after=m", "n", true},
+		{"after=m", "m", false},
+		{"contains=@", "user@example.com", true},
+		{"contains=@", "user", false},
+		{"excludes=' '", "a b", false},
+		{"excludes=' '", "ab", true},
+		{"prefix=https://", "https://example.com", true},
+		{"prefix=https://", "http://example.com", false},
+		{"suffix=.com", "example.com", true},
+		{"suffix=.com", "example.org", false},
+		{"contains_any=!@#$", "pa$$", true},
+		{"contains_any=!@#$", "pass", false},
+		{"excludes_all='<>'", "a<b", false},
+		{"excludes_all='<>'", "ab", true},
+		{"is_fold=yes", "YeS", true},
+		{"is_fold=yes", "no", false},
+		{"is_not_fold=admin", "ADMIN", false},
+		{"is_not_fold=admin", "user", true},
+		{"contains_fold=Error", "an ERROR occurred", true},
+		{"contains_fold=Error", "ok", false},
+		{"excludes_fold=password", "my PassWord", false},
+		{"excludes_fold=password", "secret", true},
+		{"prefix_fold=HTTP", "https://example.com", true},
+		{"prefix_fold=HTTP", "ftp://example.com", false},
+		{"suffix_fold=.jpg", "photo.JPG", true},
+		{"suffix_fold=.jpg", "photo.png", false},
+		{"is_fold=ΟΔΟΣ", "οδος", true},
+		{"is_fold=ΟΔΟΣ", "οδοσ", true},
+		{"is_not_fold=ς", "Σ", false},
+		{"contains_fold=Σ", "λόγος", true},
+		{"suffix_fold=ΟΣ", "λόγος", true},
+		{"prefix_fold=K", "\u212aelvin", true},
+		{"excludes_fold=k", "\u212aelvin", false},
+		{"!is=v1 & prefix=v", "v2", true},
+		{"is=a | is=b", "c", false},
+	}
+
+	for _, c := range cases {
+		err := validateTag(c.value, c.tag)
+		if c.valid && err != nil {
+			t.Errorf("%v validator does not validate %q: %v", c.tag, c.value, err)
+		} else if !c.valid {
+			if _, ok := err.(ErrorValidation); !ok {
+				t.Errorf("%v validator does not validate %q, got %v", c.tag, c.value, err)
+			}
+		}
+	}
+
+	if nil == Validate(struct {
+		field []string `validate:"> prefix=v"`
+	}{
+		field: []string{"v1", "1"},
+	}) {
+		t.Errorf("prefix validator does not validate for string elements")
+	}
+
+	if _, ok := validateTag(1, "is=1").(ErrorSyntax); !ok {
+		t.Errorf("is validator does not check a kind of a value")
+	}
+}
+
+func TestTimeVals(t *testing.T) {
+	now := time.Date(2020, 6, 15, 12, 0, 0, 0, time.UTC)
+	v := New(WithClock(func() time.Time {
+		return now
+	}))
+
+	type S struct {
+		Created  time.Time  `validate:"gte=2020-01-01T00:00:00Z & lte=now"`
+		Expires  *time.Time `validate:"nil=true | gt=now+30d"`
+		Birthday time.Time  `validate:"past=true & gte=1900-01-01"`
+		Start    time.Time  `validate:"future=true | eq=0001-01-01T00:00:00Z"`
+		End      time.Time  `validate:"gt_field=Start"`
+		Deadline time.Time  `validate:"gte=now-24h & lt=now+1d12h"`
+	}
+
+	expires := now.Add(31 * 24 * time.Hour)
+	valid := S{
+		Created:  now.Add(-time.Hour),
+		Expires:  &expires,
+		Birthday: time.Date(1990, 1, 1, 0, 0, 0, 0, time.Local),
+		End:      now,
+		Deadline: now.Add(-time.Hour),
+	}
+
+	if err := v.Validate(valid); err != nil {
+		t.Errorf("time validators do not validate a valid value: %v", err)
+	}
+
+	soon := now.Add(24 * time.Hour)
+	mutations := []func(s *S){
+		func(s *S) { s.Created = now.Add(time.Second) },
+		func(s *S) { s.Created = time.Date(2019, 12, 31, 23, 59, 59, 0, time.UTC) },
+		func(s *S) { s.Expires = &soon },
+		func(s *S) { s.Birthday = now },
+		func(s *S) { s.Birthday = time.Date(1899, 12, 31, 0, 0, 0, 0, time.UTC) },
+		func(s *S) { s.Start = now },
+		func(s *S) { s.Start, s.End = now.Add(time.Hour), now.Add(time.Hour) },
+		func(s *S) { s.Deadline = now.Add(-25 * time.Hour) },
+		func(s *S) { s.Deadline = now.Add(36 * time.Hour) },
+	}
+
+	for i, mutate := range mutations {
+		s := valid
+		mutate(&s)
+		if _, ok := v.Validate(s).(ErrorValidation); !ok {
+			t.Errorf("time validators do not validate mutation %d", i)
+		}
+	}
+
+	if nil != v.Validate(struct {
+		Start *time.Time `validate:"past=false"`
+	}{&soon}) {
+		t.Errorf("past validator does not validate a pointer")
+	}
+
+	if nil == v.Validate(struct {
+		Start *time.Time `validate:"past=false"`
+	}{}) {
+		t.Errorf("past validator validates a nil pointer")
+	}
+
+	for _, tag := range []string{"gte=yesterday", "gte=now+", "gte=now--1h", "gte=now+1x", "gte=now+d", "past=maybe"} {
+		if nil == CheckTag(timeType, tag) {
+			t.Errorf("time validator does not check syntax of %v", tag)
+		}
+	}
+
+	if nil == CheckTag(reflect.TypeOf(""), "past=true") {
+		t.Errorf("past validator does not check a kind of a field")
+	}
+
+	defer SetClock(nil)
+
+	SetClock(func() time.Time {
+		return time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	})
+
+	if nil != Validate(struct {
+		Created time.Time `validate:"past=true"`
+	}{time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC)}) {
+		t.Errorf("validator does not use its clock")
+	}
+
+	SetClock(nil)
+
+	if nil == Validate(struct {
+		Created time.Time `validate:"future=true"`
+	}{time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC)}) {
+		t.Errorf("validator does not restore its clock")
+	}
+}
+
+func TestTimeValsForUnexportedField(t *testing.T) {
+	now := time.Now()
+
+	for _, s := range []interface{}{
+		struct {
+			created time.Time `validate:"lte=now"`
+		}{now},
+		struct {
+			created *time.Time `validate:"past=true"`
+		}{&now},
+		struct {
+			Start time.Time
+			end   time.Time `validate:"gt_field=Start"`
+		}{now, now},
+		struct {
+			start time.Time
+			End   time.Time `validate:"gt_field=start"`
+		}{now, now},
+	} {
+		if _, ok := Validate(s).(ErrorSyntax); !ok {
+			t.Errorf("time validator does not report a time of an unexported field of %T", s)
+		}
+	}
+}
+
+func TestBigNumberVals(t *testing.T) {
+	huge, _ := new(big.Int).SetString("100000000000000000000000", 10)
+	float, _ := new(big.Float).SetString("0.1")
+	one := new(big.Float).SetPrec(8).SetInt64(1)
+
+	cases := []struct {
+		value interface{}
+		tag   string
+		valid bool
+	}{
+		{json.Number("10.5"), "gte=10.5 & lt=11", true},
+		{json.Number("10.49"), "gte=10.5", false},
+		{json.Number("1e30"), "gt=999999999999999999999999999999", true},
+		{json.Number("x"), "gte=0", false},
+		{json.Number("2"), "one_of=1,2.0,3", true},
+		{json.Number("4"), "one_of=1,2,3", false},
+		{huge, "gt=99999999999999999999999", true},
+		{huge, "lte=99999999999999999999999", false},
+		{huge, "one_of=1,100000000000000000000000", true},
+		{huge, "ne=1e23", false},
+		{(*big.Int)(nil), "gte=0", false},
+		{(*big.Int)(nil), "nil=true | gte=0", true},
+		{float, "eq=0.1", false},
+		{float, "gt=0.1 & lt=0.10000000000000000001", true},
+		{float, "one_of=0.1,0.2", false},
+		{new(big.Float).SetFloat64(0.1), "gt=0.1", true},
+		{new(big.Float).SetFloat64(0.5), "eq=0.5 & one_of=1/2", true},
+		{one, "lt=1.001 & eq=1", true},
+		{new(big.Float).SetInf(false), "gt=1e1000", true},
+		{new(big.Float).SetInf(true), "lt=-1e1000", true},
+		{big.NewRat(1, 3), "eq=1/3 & lt=0.3334", true},
+		{big.NewRat(1, 3), "gte=0.3334", false},
+	}
+
+	for _, c := range cases {
+		err := validateTag(c.value, c.tag)
+		if c.valid && err != nil {
+			t.Errorf("%v validator does not validate %v: %v", c.tag, c.value, err)
+		} else if !c.valid {
+			if _, ok := err.(ErrorValidation); !ok {
+				t.Errorf("%v validator does not validate %v, got %v", c.tag, c.value, err)
+			}
+		}
+	}
+
+	if _, ok := Validate(struct {
+		amount *big.Int `validate:"lte=99999999999999999999999"`
+	}{huge}).(ErrorSyntax); !ok {
+		t.Errorf("comparison validator does not report a number of an unexported field")
+	}
+
+	if _, ok := Validate(struct {
+		price json.Number `validate:"gte=2"`
+	}{"1"}).(ErrorValidation); !ok {
+		t.Errorf("comparison validator does not validate json.Number of an unexported field")
+	}
+
+	type Payment struct {
+		Amount json.Number
+		Method string `validate:"excluded_if=Amount:0"`
+	}
+
+	if nil != Validate(Payment{"1.00", "card"}) || nil == Validate(Payment{"0.00", "card"}) {
+		t.Errorf("conditional validator does not compare json.Number")
+	}
+
+	for _, tag := range []string{"gte=x", "one_of=1,x", "lte=0x"} {
+		if _, ok := CheckTag(bigIntType, tag).(Errors); !ok {
+			t.Errorf("%v validator does not check syntax", tag)
+		}
+	}
+}
+
+type StMoney struct {
+	Cents int64
+}
+
+func (m *StMoney) CompareTo(literal string) (int, error) {
+	if literal == "invalid" {
+		return 0, errors.New("money is not valid")
+	}
+
+	var dollars, cents int64
+	if _, err := fmt.Sscanf(literal, "$%d.%02d", &dollars, &cents); err != nil {
+		return 0, ErrSyntax
+	}
+
+	return int(m.Cents - dollars*100 - cents), nil
+}
+
+type StQueue struct {
+	items []int
+}
+
+func (q StQueue) Len() int {
+	return len(q.items)
+}
+
+func TestComparableVals(t *testing.T) {
+	type S struct {
+		Price   StMoney  `validate:"gte=$0.99 & lte='$100.00'"`
+		Deposit *StMoney `validate:"nil=true | gt=$0.00"`
+		Queue   StQueue  `validate:"lte=2"`
+	}
+
+	if err := Validate(S{Price: StMoney{99}, Queue: StQueue{[]int{1, 2}}}); err != nil {
+		t.Errorf("comparable validator does not validate a valid value: %v", err)
+	}
+
+	for i, s := range []S{{Price: StMoney{98}}, {Price: StMoney{10001}}, {Price: StMoney{100}, Deposit: &StMoney{}}, {Price: StMoney{100}, Queue: StQueue{[]int{1, 2, 3}}}} {
+		if _, ok := Validate(s).(ErrorValidation); !ok {
+			t.Errorf("comparable validator does not validate value %d", i)
+		}
+	}
+
+	if _, ok := Validate(struct {
+		Price *StMoney `validate:"gte=$1.00"`
+	}{}).(ErrorValidation); !ok {
+		t.Errorf("comparable validator validates a nil pointer")
+	}
+
+	if _, ok := Validate(struct {
+		Price StMoney `validate:"gte=1"`
+	}{}).(ErrorSyntax); !ok {
+		t.Errorf("comparable validator does not report ErrSyntax as a syntax error")
+	}
+
+	err := Validate(struct {
+		Price StMoney `validate:"gte=invalid"`
+	}{})
+	if e, ok := err.(ErrorValidation); !ok || !strings.Contains(e.Error(), "money is not valid") {
+		t.Errorf("comparable validator does not report an error of CompareTo, got %v", err)
+	}
+
+	if _, ok := Validate(struct {
+		price StMoney `validate:"gte=$1.00"`
+	}{}).(ErrorSyntax); !ok {
+		t.Errorf("comparable validator does not report a value of an unexported field")
+	}
+
+	if nil == CheckTag(reflect.TypeOf(StQueue{}), "lte=x") {
+		t.Errorf("lengther validator does not check syntax")
+	}
+
+	type Names struct {
+		names sort.StringSlice `validate:"lte=2"`
+	}
+
+	if err := Validate(Names{names: sort.StringSlice{"a", "b"}}); err != nil {
+		t.Errorf("lengther validator does not validate a valid value of an unexported field: %v", err)
+	}
+
+	if _, ok := Validate(Names{names: sort.StringSlice{"a", "b", "c"}}).(ErrorValidation); !ok {
+		t.Errorf("lengther validator does not validate a value of an unexported field")
+	}
+}
+
+type StUUID [16]byte
+
+func (u StUUID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:])), nil
+}
+
+type StLevel int
+
+func (l StLevel) String() string {
+	return [...]string{"debug", "info", "error"}[l]
+}
+
+func TestTextMarshalingVals(t *testing.T) {
+	type S struct {
+		IP      net.IP   `validate:"format=ip & !prefix=127."`
+		ID      StUUID   `validate:"format=uuid & eq=36"`
+		Level   StLevel  `validate:"one_of=info,error & lte=2"`
+		Link    url.URL  `validate:"empty=true | prefix=https://"`
+		Website *url.URL `validate:"nil=true | suffix=.com"`
+	}
+
+	v := New(WithTextMarshaling())
+
+	valid := S{IP: net.IPv4(10, 0, 0, 1), Level: 1, Link: url.URL{Scheme: "https", Host: "example.com"}, Website: &url.URL{Host: "example.com"}}
+	if err := v.Validate(valid); err != nil {
+		t.Errorf("text validator does not validate a valid value: %v", err)
+	}
+
+	for i, s := range []S{{IP: net.IPv4(127, 0, 0, 1)}, {IP: net.IP{1, 2, 3}}, {IP: net.IPv6loopback, Level: 0}, {IP: net.IPv6loopback, Level: 1, Link: url.URL{Scheme: "http", Host: "example.com"}}, {IP: net.IPv6loopback, Level: 1, Website: &url.URL{Host: "example.org"}}} {
+		if _, ok := v.Validate(s).(ErrorValidation); !ok {
+			t.Errorf("text validator does not validate value %d", i)
+		}
+	}
+
+	if _, ok := v.Validate(S{Level: 1}).(ErrorValidation); !ok {
+		t.Errorf("text validator validates a nil value")
+	}
+
+	err := v.Validate(S{IP: net.IP{1, 2, 3}})
+	if e, ok := err.(ErrorValidation); !ok || !strings.Contains(e.Error(), `type "net.IP"`) || !strings.Contains(e.Error(), "invalid IP address") {
+		t.Errorf("text validator does not report an original value and an error of MarshalText, got %v", err)
+	}
+
+	err = v.Validate(S{IP: net.IPv4(127, 0, 0, 1)})
+	if e, ok := err.(ErrorValidation); !ok || !strings.Contains(e.Error(), `type "net.IP"`) {
+		t.Errorf("text validator does not report an original value, got %v", err)
+	}
+
+	if _, ok := v.Validate(struct {
+		level StLevel `validate:"one_of=info"`
+	}{}).(ErrorSyntax); !ok {
+		t.Errorf("text validator does not report a value of an unexported field")
+	}
+
+	if err := v.Validate(struct {
+		Timeout time.Duration `validate:"gte=1s"`
+	}{time.Second}); err != nil {
+		t.Errorf("text validator validates time.Duration as a text: %v", err)
+	}
+
+	if _, ok := Validate(S{IP: net.IPv4(10, 0, 0, 1)}).(ErrorSyntax); !ok {
+		t.Errorf("text validator is enabled by default")
+	}
+
+	SetTextMarshaling(true)
+	defer SetTextMarshaling(false)
+	if err := Validate(valid); err != nil {
+		t.Errorf("text validator is not enabled by SetTextMarshaling: %v", err)
+	}
+}
+
 func TestDeepValsForStruct(t *testing.T) {
 	s := " "
 
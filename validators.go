@@ -1,8 +1,11 @@
 package validate
 
 import (
+	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -50,553 +53,981 @@ const (
 	// ValidatorFormat checks if a string of a given format.
 	// E.g. `validate:"format=email"`
 	ValidatorFormat ValidatorType = "format"
+
+	// ValidatorRegexp checks if a string matches a regular expression.
+	// Quote an expression if it contains whitespace, brackets, or characters used by operators.
+	// E.g. `validate:"regexp='^[a-z]+(-[a-z]+)*$'"`
+	ValidatorRegexp ValidatorType = "regexp"
+
+	// ValidatorIs checks if a string equals a given string.
+	// E.g. `validate:"is=v1"`
+	ValidatorIs ValidatorType = "is"
+
+	// ValidatorIsNot checks if a string does not equal a given string.
+	// E.g. `validate:"is_not=root"`
+	ValidatorIsNot ValidatorType = "is_not"
+
+	// ValidatorBefore checks if a string sorts before a given string, strings are compared byte-wise.
+	// E.g. `validate:"before=m"`
+	ValidatorBefore ValidatorType = "before"
+
+	// ValidatorAfter checks if a string sorts after a given string, strings are compared byte-wise.
+	// E.g. `validate:"after=m"`
+	ValidatorAfter ValidatorType = "after"
+
+	// ValidatorContains checks if a string contains a given substring.
+	// E.g. `validate:"contains=@"`
+	ValidatorContains ValidatorType = "contains"
+
+	// ValidatorExcludes checks if a string does not contain a given substring.
+	// E.g. `validate:"excludes=' '"`
+	ValidatorExcludes ValidatorType = "excludes"
+
+	// ValidatorPrefix checks if a string starts with a given prefix.
+	// E.g. `validate:"prefix=https://"`
+	ValidatorPrefix ValidatorType = "prefix"
+
+	// ValidatorSuffix checks if a string ends with a given suffix.
+	// E.g. `validate:"suffix=.com"`
+	ValidatorSuffix ValidatorType = "suffix"
+
+	// ValidatorContainsAny checks if a string contains any of the given characters.
+	// E.g. `validate:"contains_any=!@#$"`
+	ValidatorContainsAny ValidatorType = "contains_any"
+
+	// ValidatorExcludesAll checks if a string does not contain any of the given characters.
+	// E.g. `validate:"excludes_all='<>'"`
+	ValidatorExcludesAll ValidatorType = "excludes_all"
+
+	// ValidatorIsFold checks if a string equals a given string ignoring case.
+	// E.g. `validate:"is_fold=yes"`
+	ValidatorIsFold ValidatorType = "is_fold"
+
+	// ValidatorIsNotFold checks if a string does not equal a given string ignoring case.
+	// E.g. `validate:"is_not_fold=admin"`
+	ValidatorIsNotFold ValidatorType = "is_not_fold"
+
+	// ValidatorContainsFold checks if a string contains a given substring ignoring case.
+	// E.g. `validate:"contains_fold=error"`
+	ValidatorContainsFold ValidatorType = "contains_fold"
+
+	// ValidatorExcludesFold checks if a string does not contain a given substring ignoring case.
+	// E.g. `validate:"excludes_fold=password"`
+	ValidatorExcludesFold ValidatorType = "excludes_fold"
+
+	// ValidatorPrefixFold checks if a string starts with a given prefix ignoring case.
+	// E.g. `validate:"prefix_fold=http"`
+	ValidatorPrefixFold ValidatorType = "prefix_fold"
+
+	// ValidatorSuffixFold checks if a string ends with a given suffix ignoring case.
+	// E.g. `validate:"suffix_fold=.jpg"`
+	ValidatorSuffixFold ValidatorType = "suffix_fold"
+
+	// ValidatorPast checks if a time is (not) in the past, it is applied to time.Time and *time.Time.
+	// E.g. `validate:"past=true"`
+	ValidatorPast ValidatorType = "past"
+
+	// ValidatorFuture checks if a time is (not) in the future, it is applied to time.Time and *time.Time.
+	// E.g. `validate:"future=true"`
+	ValidatorFuture ValidatorType = "future"
+
+	// ValidatorEqField (equals field) compares a value with a value of another field of the same struct.
+	// Numbers are compared by value, strings are compared by content, a count of elements is compared otherwise.
+	// E.g. `validate:"eq_field=Password"`
+	ValidatorEqField ValidatorType = "eq_field"
+
+	// ValidatorNeField (not equals field) compares a value with a value of another field of the same struct.
+	// E.g. `validate:"ne_field=OldPassword"`
+	ValidatorNeField ValidatorType = "ne_field"
+
+	// ValidatorGtField (greater than field) compares a value with a value of another field of the same struct.
+	// Numbers are compared by value, a count of elements is compared otherwise.
+	// E.g. `validate:"gt_field=StartDate"`
+	ValidatorGtField ValidatorType = "gt_field"
+
+	// ValidatorLtField (less than field) compares a value with a value of another field of the same struct.
+	// E.g. `validate:"lt_field=Limits.Max"`
+	ValidatorLtField ValidatorType = "lt_field"
+
+	// ValidatorGteField (greater than or equal to field) compares a value with a value of another field of the same struct.
+	// E.g. `validate:"gte_field=MinItems"`
+	ValidatorGteField ValidatorType = "gte_field"
+
+	// ValidatorLteField (less than or equal to field) compares a value with a value of another field of the same struct.
+	// E.g. `validate:"lte_field=MaxItems"`
+	ValidatorLteField ValidatorType = "lte_field"
+
+	// ValidatorRequiredIf checks if a value is set when another field of the same struct equals any of the given values.
+	// A value is set if it is not nil, not empty, and not a zero value.
+	// E.g. `validate:"required_if=PaymentMethod:card"`
+	ValidatorRequiredIf ValidatorType = "required_if"
+
+	// ValidatorRequiredUnless checks if a value is set unless another field of the same struct equals any of the given values.
+	// E.g. `validate:"required_unless=Country:US,CA"`
+	ValidatorRequiredUnless ValidatorType = "required_unless"
+
+	// ValidatorRequiredWith checks if a value is set when any of the given fields of the same struct are set.
+	// E.g. `validate:"required_with=Street,City"`
+	ValidatorRequiredWith ValidatorType = "required_with"
+
+	// ValidatorRequiredWithout checks if a value is set when any of the given fields of the same struct are not set.
+	// E.g. `validate:"required_without=Phone"`
+	ValidatorRequiredWithout ValidatorType = "required_without"
+
+	// ValidatorExcludedIf checks if a value is not set when another field of the same struct equals any of the given values.
+	// E.g. `validate:"excluded_if=PaymentMethod:cash"`
+	ValidatorExcludedIf ValidatorType = "excluded_if"
 )
 
-// validatorFunc is an interface for validator func
-type validatorFunc func(value reflect.Value, validator string) ErrorField
+// ValidatorFunc is a custom validator function.
+// It accepts a value to validate and a validator value, e.g. "1,5" for `validate:"in_range=1,5"`.
+// Quotes are removed from a validator value, e.g. "a b" is passed for `validate:"has='a b'"`.
+// It returns nil if the value is valid and any other error if the value is not valid.
+// Such an error is reported as ErrorValidation.
+// Return ErrSyntax if the validator value could not be parsed or the validator could not be run for the value,
+// it is reported as ErrorSyntax.
+type ValidatorFunc func(value reflect.Value, validator string) error
+
+// validatorFunc is an interface for validator func.
+// It compiles a validator for a type and returns a check func
+// or a syntax error if a validator could not be parsed or run for the type.
+// Parent is a type of a struct containing a validated field or nil if it is not known.
+type validatorFunc func(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField)
+
+// checkFunc is an interface for a compiled validator func.
+// Parent is a struct containing a validated field, it is used by cross-field validators.
+type checkFunc func(value reflect.Value, parent reflect.Value) ErrorField
+
+// regexpValidatorType matches a valid validator type
+var regexpValidatorType = regexp.MustCompile(`^[[:alnum:]_]+$`)
+
+// RegisterValidator registers a custom validator, which then can be used in tags.
+// It returns an error if a validator type is not valid
+// or a validator of the same type is built-in or already registered.
+// It is safe to call RegisterValidator concurrently.
+func (v *Validator) RegisterValidator(validatorType ValidatorType, f ValidatorFunc) error {
+	return v.registerValidator(validatorType, f, false)
+}
 
-func getValidatorTypeMap() map[ValidatorType]validatorFunc {
-	return map[ValidatorType]validatorFunc{
-		ValidatorEq:     validateEq,
-		ValidatorNe:     validateNe,
-		ValidatorGt:     validateGt,
-		ValidatorLt:     validateLt,
-		ValidatorGte:    validateGte,
-		ValidatorLte:    validateLte,
-		ValidatorEmpty:  validateEmpty,
-		ValidatorNil:    validateNil,
-		ValidatorOneOf:  validateOneOf,
-		ValidatorFormat: validateFormat,
-	}
+// OverrideValidator registers a custom validator the same way RegisterValidator does,
+// but it replaces a built-in or already registered validator of the same type.
+func (v *Validator) OverrideValidator(validatorType ValidatorType, f ValidatorFunc) error {
+	return v.registerValidator(validatorType, f, true)
 }
 
-type validator struct {
-	Type  ValidatorType
-	Value string
+// RegisterValidator registers a custom validator used by package level functions.
+// See Validator.RegisterValidator for details.
+//
+//  validate.RegisterValidator("even", func(value reflect.Value, validator string) error {
+//  	if value.Kind() != reflect.Int {
+//  		return validate.ErrSyntax
+//  	}
+//  	if value.Int()%2 != 0 {
+//  		return errors.New("value should be even")
+//  	}
+//  	return nil
+//  })
+func RegisterValidator(validatorType ValidatorType, f ValidatorFunc) error {
+	return defaultValidator.RegisterValidator(validatorType, f)
 }
 
-func validateEq(value reflect.Value, validator string) ErrorField {
-	kind := value.Kind()
-	typ := value.Type()
+// OverrideValidator registers a custom validator used by package level functions.
+// See Validator.OverrideValidator for details.
+func OverrideValidator(validatorType ValidatorType, f ValidatorFunc) error {
+	return defaultValidator.OverrideValidator(validatorType, f)
+}
 
-	errorValidation := ErrorValidation{
-		fieldValue:     value,
-		validatorType:  ValidatorEq,
-		validatorValue: validator,
+// registerValidator registers a custom validator
+func (v *Validator) registerValidator(validatorType ValidatorType, f ValidatorFunc, override bool) error {
+	if !regexpValidatorType.MatchString(string(validatorType)) {
+		return fmt.Errorf("validator type \"%v\" is not valid", validatorType)
 	}
-
-	errorSyntax := ErrorSyntax{
-		expression: validator,
-		near:       string(ValidatorEq),
-		comment:    "could not parse or run",
+	if f == nil {
+		return fmt.Errorf("validator \"%v\" is nil", validatorType)
 	}
 
-	switch kind {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if typ == reflect.TypeOf((time.Duration)(0)) {
-			if token, err := time.ParseDuration(validator); err != nil {
-				return errorSyntax
-			} else if time.Duration(value.Int()) != token {
-				return errorValidation
-			}
-		} else {
-			if token, err := strconv.ParseInt(validator, 10, 64); err != nil {
-				return errorSyntax
-			} else if value.Int() != token {
-				return errorValidation
-			}
-		}
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		if token, err := strconv.ParseUint(validator, 10, 64); err != nil {
-			return errorSyntax
-		} else if value.Uint() != token {
-			return errorValidation
-		}
-	case reflect.Float32, reflect.Float64:
-		if token, err := strconv.ParseFloat(validator, 64); err != nil {
-			return errorSyntax
-		} else if value.Float() != token {
-			return errorValidation
-		}
-	case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
-		if token, err := strconv.Atoi(validator); err != nil {
-			return errorSyntax
-		} else if value.Len() != token {
-			return errorValidation
-		}
-	default:
-		return errorSyntax
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if _, ok := v.validators[validatorType]; ok && !override {
+		return fmt.Errorf("validator \"%v\" is already registered", validatorType)
 	}
 
+	v.validators[validatorType] = customValidatorFunc(validatorType, f)
+	v.resetPlans()
+
 	return nil
 }
 
-func validateNe(value reflect.Value, validator string) ErrorField {
-	kind := value.Kind()
-	typ := value.Type()
+// customValidatorFunc converts a custom validator into a validator func
+func customValidatorFunc(validatorType ValidatorType, f ValidatorFunc) validatorFunc {
+	return func(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+		return func(value reflect.Value, parent reflect.Value) ErrorField {
+			return callValidatorFunc(validatorType, f, value, validator)
+		}, nil
+	}
+}
+
+// callValidatorFunc calls a custom validator and converts its error
+func callValidatorFunc(validatorType ValidatorType, f ValidatorFunc, value reflect.Value, validator string) ErrorField {
+	err := f(value, validator)
 
-	errorValidation := ErrorValidation{
+	switch err.(type) {
+	case nil:
+		return nil
+	case ErrorValidation, ErrorSyntax:
+		return err.(ErrorField)
+	}
+
+	if err == ErrSyntax {
+		return ErrorSyntax{
+			expression: validator,
+			near:       string(validatorType),
+			comment:    err.Error(),
+		}
+	}
+
+	return ErrorValidation{
 		fieldValue:     value,
-		validatorType:  ValidatorNe,
+		validatorType:  validatorType,
 		validatorValue: validator,
+		err:            err,
 	}
+}
 
-	errorSyntax := ErrorSyntax{
-		expression: validator,
-		near:       string(ValidatorNe),
-		comment:    "could not parse or run",
+func (v *Validator) getValidatorTypeMap() map[ValidatorType]validatorFunc {
+	return map[ValidatorType]validatorFunc{
+		ValidatorEq:     v.validateEq,
+		ValidatorNe:     v.validateNe,
+		ValidatorGt:     v.validateGt,
+		ValidatorLt:     v.validateLt,
+		ValidatorGte:    v.validateGte,
+		ValidatorLte:    v.validateLte,
+		ValidatorEmpty:  validateEmpty,
+		ValidatorNil:    validateNil,
+		ValidatorOneOf:  validateOneOf,
+		ValidatorFormat: v.validateFormat,
+		ValidatorRegexp: validateRegexp,
+		ValidatorPast:   v.validatePast,
+		ValidatorFuture: v.validateFuture,
+
+		ValidatorIs:           stringValidatorFunc(ValidatorIs, stringIs, false),
+		ValidatorIsNot:        stringValidatorFunc(ValidatorIsNot, stringIsNot, false),
+		ValidatorBefore:       stringValidatorFunc(ValidatorBefore, stringBefore, false),
+		ValidatorAfter:        stringValidatorFunc(ValidatorAfter, stringAfter, false),
+		ValidatorContains:     stringValidatorFunc(ValidatorContains, strings.Contains, false),
+		ValidatorExcludes:     stringValidatorFunc(ValidatorExcludes, stringExcludes, false),
+		ValidatorPrefix:       stringValidatorFunc(ValidatorPrefix, strings.HasPrefix, false),
+		ValidatorSuffix:       stringValidatorFunc(ValidatorSuffix, strings.HasSuffix, false),
+		ValidatorContainsAny:  stringValidatorFunc(ValidatorContainsAny, strings.ContainsAny, false),
+		ValidatorExcludesAll:  stringValidatorFunc(ValidatorExcludesAll, stringExcludesAll, false),
+		ValidatorIsFold:       stringValidatorFunc(ValidatorIsFold, strings.EqualFold, false),
+		ValidatorIsNotFold:    stringValidatorFunc(ValidatorIsNotFold, stringIsNotFold, false),
+		ValidatorContainsFold: stringValidatorFunc(ValidatorContainsFold, strings.Contains, true),
+		ValidatorExcludesFold: stringValidatorFunc(ValidatorExcludesFold, stringExcludes, true),
+		ValidatorPrefixFold:   stringValidatorFunc(ValidatorPrefixFold, strings.HasPrefix, true),
+		ValidatorSuffixFold:   stringValidatorFunc(ValidatorSuffixFold, strings.HasSuffix, true),
+
+		ValidatorEqField:  v.validateEqField,
+		ValidatorNeField:  v.validateNeField,
+		ValidatorGtField:  v.validateGtField,
+		ValidatorLtField:  v.validateLtField,
+		ValidatorGteField: v.validateGteField,
+		ValidatorLteField: v.validateLteField,
+
+		ValidatorRequiredIf:      validateRequiredIf,
+		ValidatorRequiredUnless:  validateRequiredUnless,
+		ValidatorRequiredWith:    validateRequiredWith,
+		ValidatorRequiredWithout: validateRequiredWithout,
+		ValidatorExcludedIf:      validateExcludedIf,
 	}
+}
 
-	switch kind {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if typ == reflect.TypeOf((time.Duration)(0)) {
-			if token, err := time.ParseDuration(validator); err != nil {
-				return errorSyntax
-			} else if time.Duration(value.Int()) == token {
-				return errorValidation
-			}
-		} else {
-			if token, err := strconv.ParseInt(validator, 10, 64); err != nil {
-				return errorSyntax
-			} else if value.Int() == token {
-				return errorValidation
-			}
-		}
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		if token, err := strconv.ParseUint(validator, 10, 64); err != nil {
-			return errorSyntax
-		} else if value.Uint() == token {
-			return errorValidation
-		}
-	case reflect.Float32, reflect.Float64:
-		if token, err := strconv.ParseFloat(validator, 64); err != nil {
-			return errorSyntax
-		} else if value.Float() == token {
-			return errorValidation
-		}
-	case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
-		if token, err := strconv.Atoi(validator); err != nil {
-			return errorSyntax
-		} else if value.Len() == token {
-			return errorValidation
-		}
-	default:
-		return errorSyntax
+type validator struct {
+	Type    ValidatorType
+	Value   string
+	Negated bool
+}
+
+// listValidators are validators which values are lists of tokens, quotes are removed from every token by a validator
+var listValidators = map[ValidatorType]bool{
+	ValidatorOneOf:           true,
+	ValidatorRequiredIf:      true,
+	ValidatorRequiredUnless:  true,
+	ValidatorRequiredWith:    true,
+	ValidatorRequiredWithout: true,
+	ValidatorExcludedIf:      true,
+}
+
+// token gets a value passed to a validator func, quotes are removed unless a value is a list of tokens
+func (v validator) token() string {
+	if listValidators[v.Type] {
+		return v.Value
 	}
 
-	return nil
+	return unquote(v.Value)
 }
 
-func validateGt(value reflect.Value, validator string) ErrorField {
-	kind := value.Kind()
-	typ := value.Type()
+// durationType is a type of time.Duration
+var durationType = reflect.TypeOf((time.Duration)(0))
 
-	errorValidation := ErrorValidation{
-		fieldValue:     value,
-		validatorType:  ValidatorGt,
-		validatorValue: validator,
+// compareOp is a comparison operator used by comparison validators
+type compareOp int
+
+// Following comparison operators are available.
+const (
+	compareEq compareOp = iota
+	compareNe
+	compareGt
+	compareLt
+	compareGte
+	compareLte
+)
+
+// compareInt compares signed integers
+func (op compareOp) compareInt(a, b int64) bool {
+	switch op {
+	case compareEq:
+		return a == b
+	case compareNe:
+		return a != b
+	case compareGt:
+		return a > b
+	case compareLt:
+		return a < b
+	case compareGte:
+		return a >= b
+	case compareLte:
+		return a <= b
 	}
 
-	errorSyntax := ErrorSyntax{
-		expression: validator,
-		near:       string(ValidatorGt),
-		comment:    "could not parse or run",
+	return false
+}
+
+// compareUint compares unsigned integers
+func (op compareOp) compareUint(a, b uint64) bool {
+	switch op {
+	case compareEq:
+		return a == b
+	case compareNe:
+		return a != b
+	case compareGt:
+		return a > b
+	case compareLt:
+		return a < b
+	case compareGte:
+		return a >= b
+	case compareLte:
+		return a <= b
 	}
 
-	switch kind {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if typ == reflect.TypeOf((time.Duration)(0)) {
-			if token, err := time.ParseDuration(validator); err != nil {
-				return errorSyntax
-			} else if time.Duration(value.Int()) <= token {
-				return errorValidation
-			}
-		} else {
-			if token, err := strconv.ParseInt(validator, 10, 64); err != nil {
-				return errorSyntax
-			} else if value.Int() <= token {
-				return errorValidation
-			}
-		}
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		if token, err := strconv.ParseUint(validator, 10, 64); err != nil {
-			return errorSyntax
-		} else if value.Uint() <= token {
-			return errorValidation
-		}
-	case reflect.Float32, reflect.Float64:
-		if token, err := strconv.ParseFloat(validator, 64); err != nil {
-			return errorSyntax
-		} else if value.Float() <= token {
-			return errorValidation
-		}
-	case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
-		if token, err := strconv.Atoi(validator); err != nil {
-			return errorSyntax
-		} else if value.Len() <= token {
-			return errorValidation
-		}
-	default:
-		return errorSyntax
+	return false
+}
+
+// compareFloat compares floats
+func (op compareOp) compareFloat(a, b float64) bool {
+	switch op {
+	case compareEq:
+		return a == b
+	case compareNe:
+		return a != b
+	case compareGt:
+		return a > b
+	case compareLt:
+		return a < b
+	case compareGte:
+		return a >= b
+	case compareLte:
+		return a <= b
 	}
 
-	return nil
+	return false
 }
 
-func validateLt(value reflect.Value, validator string) ErrorField {
-	kind := value.Kind()
-	typ := value.Type()
+func (v *Validator) validateEq(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return v.validateCompare(ValidatorEq, compareEq, typ, validator)
+}
 
-	errorValidation := ErrorValidation{
-		fieldValue:     value,
-		validatorType:  ValidatorLt,
-		validatorValue: validator,
+func (v *Validator) validateNe(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return v.validateCompare(ValidatorNe, compareNe, typ, validator)
+}
+
+func (v *Validator) validateGt(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return v.validateCompare(ValidatorGt, compareGt, typ, validator)
+}
+
+func (v *Validator) validateLt(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return v.validateCompare(ValidatorLt, compareLt, typ, validator)
+}
+
+func (v *Validator) validateGte(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return v.validateCompare(ValidatorGte, compareGte, typ, validator)
+}
+
+func (v *Validator) validateLte(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return v.validateCompare(ValidatorLte, compareLte, typ, validator)
+}
+
+// validateCompare compiles a comparison validator, v.mutex must be held
+func (v *Validator) validateCompare(validatorType ValidatorType, op compareOp, typ reflect.Type, validator string) (checkFunc, ErrorField) {
+	if implements(typ, comparableType) {
+		return validateCompareComparable(validatorType, op, validator)
+	}
+	if implements(typ, lengtherType) {
+		return validateCompareLengther(validatorType, op, typ, validator, v.stringLength)
+	}
+	if isTime(typ) {
+		return validateCompareTime(validatorType, op, typ, validator, v.clock)
+	}
+	if isBigNumber(typ) {
+		return validateCompareBig(validatorType, op, validator)
+	}
+
+	errorValidation := func(value reflect.Value) ErrorField {
+		return ErrorValidation{
+			fieldValue:     value,
+			validatorType:  validatorType,
+			validatorValue: validator,
+		}
 	}
 
 	errorSyntax := ErrorSyntax{
 		expression: validator,
-		near:       string(ValidatorLt),
+		near:       string(validatorType),
 		comment:    "could not parse or run",
 	}
 
-	switch kind {
+	switch typ.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if typ == reflect.TypeOf((time.Duration)(0)) {
-			if token, err := time.ParseDuration(validator); err != nil {
-				return errorSyntax
-			} else if time.Duration(value.Int()) >= token {
-				return errorValidation
+		var token int64
+		if typ == durationType {
+			duration, err := time.ParseDuration(validator)
+			if err != nil {
+				return nil, errorSyntax
 			}
+			token = int64(duration)
 		} else {
-			if token, err := strconv.ParseInt(validator, 10, 64); err != nil {
-				return errorSyntax
-			} else if value.Int() >= token {
-				return errorValidation
+			var err error
+			if token, err = strconv.ParseInt(validator, 10, 64); err != nil {
+				return nil, errorSyntax
 			}
 		}
+		return func(value reflect.Value, parent reflect.Value) ErrorField {
+			if !op.compareInt(value.Int(), token) {
+				return errorValidation(value)
+			}
+			return nil
+		}, nil
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		if token, err := strconv.ParseUint(validator, 10, 64); err != nil {
-			return errorSyntax
-		} else if value.Uint() >= token {
-			return errorValidation
+		token, err := strconv.ParseUint(validator, 10, 64)
+		if err != nil {
+			return nil, errorSyntax
 		}
+		return func(value reflect.Value, parent reflect.Value) ErrorField {
+			if !op.compareUint(value.Uint(), token) {
+				return errorValidation(value)
+			}
+			return nil
+		}, nil
 	case reflect.Float32, reflect.Float64:
-		if token, err := strconv.ParseFloat(validator, 64); err != nil {
-			return errorSyntax
-		} else if value.Float() >= token {
-			return errorValidation
+		token, err := strconv.ParseFloat(validator, 64)
+		if err != nil {
+			return nil, errorSyntax
 		}
+		return func(value reflect.Value, parent reflect.Value) ErrorField {
+			if !op.compareFloat(value.Float(), token) {
+				return errorValidation(value)
+			}
+			return nil
+		}, nil
 	case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
-		if token, err := strconv.Atoi(validator); err != nil {
-			return errorSyntax
-		} else if value.Len() >= token {
-			return errorValidation
+		token, err := strconv.Atoi(validator)
+		if err != nil {
+			return nil, errorSyntax
 		}
-	default:
-		return errorSyntax
+		unit := v.stringLength
+		return func(value reflect.Value, parent reflect.Value) ErrorField {
+			if !op.compareInt(int64(unit.len(value)), int64(token)) {
+				return errorValidation(value)
+			}
+			return nil
+		}, nil
 	}
 
-	return nil
+	return nil, errorSyntax
 }
 
-func validateGte(value reflect.Value, validator string) ErrorField {
-	kind := value.Kind()
-	typ := value.Type()
+func validateEmpty(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	errorSyntax := ErrorSyntax{
+		expression: validator,
+		near:       string(ValidatorEmpty),
+		comment:    "could not parse or run",
+	}
 
-	errorValidation := ErrorValidation{
-		fieldValue:     value,
-		validatorType:  ValidatorGte,
-		validatorValue: validator,
+	switch typ.Kind() {
+	case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
+		isEmpty, err := strconv.ParseBool(validator)
+		if err != nil {
+			return nil, errorSyntax
+		}
+		return func(value reflect.Value, parent reflect.Value) ErrorField {
+			if isEmpty != (value.Len() == 0) {
+				return ErrorValidation{
+					fieldValue:     value,
+					validatorType:  ValidatorEmpty,
+					validatorValue: validator,
+				}
+			}
+			return nil
+		}, nil
 	}
 
+	return nil, errorSyntax
+}
+
+func validateNil(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
 	errorSyntax := ErrorSyntax{
 		expression: validator,
-		near:       string(ValidatorGte),
+		near:       string(ValidatorNil),
 		comment:    "could not parse or run",
 	}
 
-	switch kind {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if typ == reflect.TypeOf((time.Duration)(0)) {
-			if token, err := time.ParseDuration(validator); err != nil {
-				return errorSyntax
-			} else if time.Duration(value.Int()) < token {
-				return errorValidation
-			}
-		} else {
-			if token, err := strconv.ParseInt(validator, 10, 64); err != nil {
-				return errorSyntax
-			} else if value.Int() < token {
-				return errorValidation
-			}
-		}
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		if token, err := strconv.ParseUint(validator, 10, 64); err != nil {
-			return errorSyntax
-		} else if value.Uint() < token {
-			return errorValidation
-		}
-	case reflect.Float32, reflect.Float64:
-		if token, err := strconv.ParseFloat(validator, 64); err != nil {
-			return errorSyntax
-		} else if value.Float() < token {
-			return errorValidation
-		}
-	case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
-		if token, err := strconv.Atoi(validator); err != nil {
-			return errorSyntax
-		} else if value.Len() < token {
-			return errorValidation
+	switch typ.Kind() {
+	case reflect.Ptr:
+		isNil, err := strconv.ParseBool(validator)
+		if err != nil {
+			return nil, errorSyntax
 		}
-	default:
-		return errorSyntax
+		return func(value reflect.Value, parent reflect.Value) ErrorField {
+			if isNil != value.IsNil() {
+				return ErrorValidation{
+					fieldValue:     value,
+					validatorType:  ValidatorNil,
+					validatorValue: validator,
+				}
+			}
+			return nil
+		}, nil
 	}
 
-	return nil
+	return nil, errorSyntax
 }
 
-func validateLte(value reflect.Value, validator string) ErrorField {
-	kind := value.Kind()
-	typ := value.Type()
-
-	errorValidation := ErrorValidation{
-		fieldValue:     value,
-		validatorType:  ValidatorLte,
-		validatorValue: validator,
+func validateOneOf(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	if isBigNumber(typ) {
+		return validateOneOfBig(validator)
 	}
 
 	errorSyntax := ErrorSyntax{
 		expression: validator,
-		near:       string(ValidatorLte),
+		near:       string(ValidatorOneOf),
 		comment:    "could not parse or run",
 	}
 
-	switch kind {
+	var tokens []interface{}
+	if tokens = parseTokens(validator); len(tokens) == 0 {
+		return nil, errorSyntax
+	}
+
+	var get func(value reflect.Value) interface{}
+	var parse func(token string) (interface{}, error)
+
+	switch typ.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if typ == reflect.TypeOf((time.Duration)(0)) {
-			if token, err := time.ParseDuration(validator); err != nil {
-				return errorSyntax
-			} else if time.Duration(value.Int()) > token {
-				return errorValidation
-			}
+		if typ == durationType {
+			get = func(value reflect.Value) interface{} { return time.Duration(value.Int()) }
+			parse = func(token string) (interface{}, error) { return time.ParseDuration(token) }
 		} else {
-			if token, err := strconv.ParseInt(validator, 10, 64); err != nil {
-				return errorSyntax
-			} else if value.Int() > token {
-				return errorValidation
-			}
+			get = func(value reflect.Value) interface{} { return value.Int() }
+			parse = func(token string) (interface{}, error) { return strconv.ParseInt(token, 10, 64) }
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		if token, err := strconv.ParseUint(validator, 10, 64); err != nil {
-			return errorSyntax
-		} else if value.Uint() > token {
-			return errorValidation
-		}
+		get = func(value reflect.Value) interface{} { return value.Uint() }
+		parse = func(token string) (interface{}, error) { return strconv.ParseUint(token, 10, 64) }
 	case reflect.Float32, reflect.Float64:
-		if token, err := strconv.ParseFloat(validator, 64); err != nil {
-			return errorSyntax
-		} else if value.Float() > token {
-			return errorValidation
-		}
-	case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
-		if token, err := strconv.Atoi(validator); err != nil {
-			return errorSyntax
-		} else if value.Len() > token {
-			return errorValidation
-		}
+		get = func(value reflect.Value) interface{} { return value.Float() }
+		parse = func(token string) (interface{}, error) { return strconv.ParseFloat(token, 64) }
+	case reflect.String:
+		get = func(value reflect.Value) interface{} { return value.String() }
 	default:
-		return errorSyntax
+		return nil, errorSyntax
 	}
 
-	return nil
-}
-
-func validateEmpty(value reflect.Value, validator string) ErrorField {
-	kind := value.Kind()
-
-	errorValidation := ErrorValidation{
-		fieldValue:     value,
-		validatorType:  ValidatorEmpty,
-		validatorValue: validator,
+	if parse != nil {
+		for i, token := range tokens {
+			var err error
+			if tokens[i], err = parse(token.(string)); err != nil {
+				return nil, errorSyntax
+			}
+		}
 	}
 
+	return func(value reflect.Value, parent reflect.Value) ErrorField {
+		if !tokenOneOf(get(value), tokens) {
+			return ErrorValidation{
+				fieldValue:     value,
+				validatorType:  ValidatorOneOf,
+				validatorValue: validator,
+			}
+		}
+		return nil
+	}, nil
+}
+
+// validateFormat compiles a format validator, v.mutex must be held
+func (v *Validator) validateFormat(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
 	errorSyntax := ErrorSyntax{
 		expression: validator,
-		near:       string(ValidatorEmpty),
-		comment:    "could not parse or run",
+		near:       string(ValidatorFormat),
+		comment:    "could not find format",
 	}
 
-	switch kind {
-	case reflect.String, reflect.Map, reflect.Slice, reflect.Array:
-		if isEmpty, err := strconv.ParseBool(validator); err != nil {
-			return errorSyntax
-		} else if isEmpty && value.Len() > 0 {
-			return errorValidation
-		} else if !isEmpty && value.Len() == 0 {
-			return errorValidation
+	switch typ.Kind() {
+	case reflect.String:
+		formatFunc, ok := v.formats[FormatType(validator)]
+		if !ok {
+			return nil, errorSyntax
 		}
-	default:
-		return errorSyntax
+		return func(value reflect.Value, parent reflect.Value) ErrorField {
+			if !formatFunc(value.String()) {
+				return ErrorValidation{
+					fieldValue:     value,
+					validatorType:  ValidatorFormat,
+					validatorValue: validator,
+				}
+			}
+			return nil
+		}, nil
 	}
 
-	return nil
+	return nil, errorSyntax
 }
 
-func validateNil(value reflect.Value, validator string) ErrorField {
-	kind := value.Kind()
+// validateRegexp compiles a regexp validator, a regular expression is compiled once per plan
+func validateRegexp(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	re, err := compileRegexp(validator)
+	if err != nil {
+		return nil, err
+	}
 
-	errorValidation := ErrorValidation{
-		fieldValue:     value,
-		validatorType:  ValidatorNil,
-		validatorValue: validator,
+	if typ.Kind() != reflect.String {
+		return nil, ErrorSyntax{
+			expression: validator,
+			near:       string(ValidatorRegexp),
+			comment:    "could not parse or run",
+		}
 	}
 
-	errorSyntax := ErrorSyntax{
-		expression: validator,
-		near:       string(ValidatorNil),
-		comment:    "could not parse or run",
+	return func(value reflect.Value, parent reflect.Value) ErrorField {
+		if !re.MatchString(value.String()) {
+			return ErrorValidation{
+				fieldValue:     value,
+				validatorType:  ValidatorRegexp,
+				validatorValue: validator,
+			}
+		}
+		return nil
+	}, nil
+}
+
+// compileRegexp compiles a regular expression of a regexp validator
+func compileRegexp(validator string) (*regexp.Regexp, ErrorField) {
+	if validator == "" {
+		return nil, ErrorSyntax{
+			expression: validator,
+			near:       string(ValidatorRegexp),
+			comment:    "could not parse",
+		}
 	}
 
-	switch kind {
-	case reflect.Ptr:
-		if isNil, err := strconv.ParseBool(validator); err != nil {
-			return errorSyntax
-		} else if isNil && !value.IsNil() {
-			return errorValidation
-		} else if !isNil && value.IsNil() {
-			return errorValidation
+	re, err := regexp.Compile(validator)
+	if err != nil {
+		return nil, ErrorSyntax{
+			expression: validator,
+			near:       string(ValidatorRegexp),
+			comment:    fmt.Sprintf("could not compile: %v", err),
 		}
-	default:
-		return errorSyntax
 	}
 
-	return nil
+	return re, nil
 }
 
-func validateOneOf(value reflect.Value, validator string) ErrorField {
-	kind := value.Kind()
-	typ := value.Type()
+func (v *Validator) validateEqField(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return validateCompareField(ValidatorEqField, compareEq, typ, validator, parent, v.stringLength)
+}
 
-	errorValidation := ErrorValidation{
-		fieldValue:     value,
-		validatorType:  ValidatorOneOf,
-		validatorValue: validator,
-	}
+func (v *Validator) validateNeField(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return validateCompareField(ValidatorNeField, compareNe, typ, validator, parent, v.stringLength)
+}
 
-	errorSyntax := ErrorSyntax{
-		expression: validator,
-		near:       string(ValidatorOneOf),
-		comment:    "could not parse or run",
+func (v *Validator) validateGtField(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return validateCompareField(ValidatorGtField, compareGt, typ, validator, parent, v.stringLength)
+}
+
+func (v *Validator) validateLtField(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return validateCompareField(ValidatorLtField, compareLt, typ, validator, parent, v.stringLength)
+}
+
+func (v *Validator) validateGteField(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return validateCompareField(ValidatorGteField, compareGte, typ, validator, parent, v.stringLength)
+}
+
+func (v *Validator) validateLteField(typ reflect.Type, validator string, parent reflect.Type) (checkFunc, ErrorField) {
+	return validateCompareField(ValidatorLteField, compareLte, typ, validator, parent, v.stringLength)
+}
+
+// regexpFieldPath matches a path to a field, e.g. Limits.Max
+var regexpFieldPath = regexp.MustCompile(`^[\pL_][\pL\pN_]*(\.[\pL_][\pL\pN_]*)*$`)
+
+// validateCompareField compiles a cross-field comparison validator.
+// A path to a field is resolved when a validator is compiled if a type of a parent struct is known,
+// otherwise it is resolved using a parent struct passed to a check func. A length of a string is counted in the unit.
+func validateCompareField(validatorType ValidatorType, op compareOp, typ reflect.Type, validator string, parent reflect.Type, unit StringLength) (checkFunc, ErrorField) {
+	if !regexpFieldPath.MatchString(validator) {
+		return nil, ErrorSyntax{
+			expression: validator,
+			near:       string(validatorType),
+			comment:    "could not parse",
+		}
 	}
 
-	switch kind {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if typ == reflect.TypeOf((time.Duration)(0)) {
-			var tokens []interface{}
-			if tokens = parseTokens(validator); len(tokens) == 0 {
-				return errorSyntax
-			}
-			for i, token := range tokens {
-				tokens[i] = nil
-				if token, err := time.ParseDuration(token.(string)); err != nil {
-					return errorSyntax
-				} else {
-					tokens[i] = token
-				}
-			}
-			if !tokenOneOf(time.Duration(value.Int()), tokens) {
-				return errorValidation
-			}
-		} else {
-			var tokens []interface{}
-			if tokens = parseTokens(validator); len(tokens) == 0 {
-				return errorSyntax
-			}
-			for i, token := range tokens {
-				tokens[i] = nil
-				if token, err := strconv.ParseInt(token.(string), 10, 64); err != nil {
-					return errorSyntax
-				} else {
-					tokens[i] = token
+	if parent == nil {
+		return func(value reflect.Value, parent reflect.Value) ErrorField {
+			if !parent.IsValid() {
+				return ErrorSyntax{
+					expression: validator,
+					near:       string(validatorType),
+					comment:    "could not find field",
 				}
 			}
-			if !tokenOneOf(value.Int(), tokens) {
-				return errorValidation
+			check, err := validateCompareField(validatorType, op, typ, validator, parent.Type(), unit)
+			if err != nil {
+				return err
 			}
+			return check(value, parent)
+		}, nil
+	}
+
+	index, fieldType, ok := findField(parent, validator)
+	if !ok {
+		return nil, ErrorSyntax{
+			expression: validator,
+			near:       string(validatorType),
+			comment:    "could not find field",
 		}
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		var tokens []interface{}
-		if tokens = parseTokens(validator); len(tokens) == 0 {
-			return errorSyntax
+	}
+
+	compare := compareValues(op, indirectType(typ), indirectType(fieldType), unit)
+	if compare == nil {
+		return nil, ErrorSyntax{
+			expression: validator,
+			near:       string(validatorType),
+			comment:    "could not compare",
 		}
-		for i, token := range tokens {
-			tokens[i] = nil
-			if token, err := strconv.ParseUint(token.(string), 10, 64); err != nil {
-				return errorSyntax
-			} else {
-				tokens[i] = token
+	}
+
+	return func(value reflect.Value, parent reflect.Value) ErrorField {
+		field, ok := fieldByIndex(parent, index)
+		if ok {
+			field, ok = indirect(field)
+		}
+		elem, elemOk := indirect(value)
+		if ok && elemOk && isTime(elem.Type()) && (!elem.CanInterface() || !field.CanInterface()) {
+			return errorUnexportedTime(validatorType, validator)
+		}
+		if !ok || !elemOk || !compare(elem, field) {
+			return ErrorValidation{
+				fieldValue:     value,
+				validatorType:  validatorType,
+				validatorValue: validator,
 			}
 		}
-		if !tokenOneOf(value.Uint(), tokens) {
-			return errorValidation
+		return nil
+	}, nil
+}
+
+// findField finds a field of a struct by a dotted path, pointers to structs are dereferenced.
+// It returns indexes of fields and a type of a field.
+func findField(typ reflect.Type, path string) ([][]int, reflect.Type, bool) {
+	var index [][]int
+	for _, name := range strings.Split(path, ".") {
+		typ = indirectType(typ)
+		if typ.Kind() != reflect.Struct {
+			return nil, nil, false
 		}
-	case reflect.Float32, reflect.Float64:
-		var tokens []interface{}
-		if tokens = parseTokens(validator); len(tokens) == 0 {
-			return errorSyntax
+		field, ok := typ.FieldByName(name)
+		if !ok {
+			return nil, nil, false
 		}
-		for i, token := range tokens {
-			tokens[i] = nil
-			if token, err := strconv.ParseFloat(token.(string), 64); err != nil {
-				return errorSyntax
-			} else {
-				tokens[i] = token
+		index = append(index, field.Index)
+		typ = field.Type
+	}
+
+	return index, typ, true
+}
+
+// fieldByIndex gets a field of a struct found by findField.
+// It returns false if a field could not be reached because of a nil pointer.
+func fieldByIndex(value reflect.Value, index [][]int) (reflect.Value, bool) {
+	for _, fieldIndex := range index {
+		for _, i := range fieldIndex {
+			var ok bool
+			if value, ok = indirect(value); !ok {
+				return reflect.Value{}, false
 			}
+			value = value.Field(i)
 		}
-		if !tokenOneOf(value.Float(), tokens) {
-			return errorValidation
+	}
+
+	return value, true
+}
+
+// indirectType dereferences pointer types
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
+
+// indirect dereferences pointers, it returns false if a pointer is nil
+func indirect(value reflect.Value) (reflect.Value, bool) {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return reflect.Value{}, false
 		}
+		value = value.Elem()
+	}
+
+	return value, true
+}
+
+// valueClass is a class of values which could be compared with each other
+type valueClass int
+
+// Following value classes are available.
+const (
+	classNone valueClass = iota
+	classNumber
+	classString
+	classLen
+	classBool
+	classTime
+)
+
+// getValueClass gets a class of values of a type
+func getValueClass(typ reflect.Type) valueClass {
+	if typ == timeType {
+		return classTime
+	}
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return classNumber
 	case reflect.String:
-		var tokens []interface{}
-		if tokens = parseTokens(validator); len(tokens) == 0 {
-			return errorSyntax
+		return classString
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return classLen
+	case reflect.Bool:
+		return classBool
+	}
+
+	return classNone
+}
+
+// compareValues gets a func comparing values of given types or nil if they could not be compared.
+// Numbers are compared by value, strings are compared by content for equality,
+// a count of elements is compared otherwise, a count of elements may be compared with a number.
+// A length of a string is counted in the unit.
+func compareValues(op compareOp, a, b reflect.Type, unit StringLength) func(a, b reflect.Value) bool {
+	classA, classB := getValueClass(a), getValueClass(b)
+	equality := op == compareEq || op == compareNe
+
+	switch {
+	case classA == classNumber && classB == classNumber:
+		return op.compareNumbers
+	case classA == classString && classB == classString && equality:
+		return func(a, b reflect.Value) bool {
+			return (a.String() == b.String()) == (op == compareEq)
 		}
-		if !tokenOneOf(value.String(), tokens) {
-			return errorValidation
+	case classA == classTime && classB == classTime:
+		return func(a, b reflect.Value) bool {
+			return op.compareTime(a.Interface().(time.Time), b.Interface().(time.Time))
+		}
+	case classA == classBool && classB == classBool && equality:
+		return func(a, b reflect.Value) bool {
+			return (a.Bool() == b.Bool()) == (op == compareEq)
+		}
+	case (classA == classString || classA == classLen) && (classB == classString || classB == classLen):
+		return func(a, b reflect.Value) bool {
+			return op.compareInt(int64(unit.len(a)), int64(unit.len(b)))
+		}
+	case (classA == classString || classA == classLen) && classB == classNumber:
+		return func(a, b reflect.Value) bool {
+			return op.compareNumbers(reflect.ValueOf(int64(unit.len(a))), b)
+		}
+	case classA == classNumber && (classB == classString || classB == classLen):
+		return func(a, b reflect.Value) bool {
+			return op.compareNumbers(a, reflect.ValueOf(int64(unit.len(b))))
 		}
-	default:
-		return errorSyntax
 	}
 
 	return nil
 }
 
-func validateFormat(value reflect.Value, validator string) ErrorField {
-	kind := value.Kind()
+// compareNumbers compares numbers of any kind
+func (op compareOp) compareNumbers(a, b reflect.Value) bool {
+	switch {
+	case isInt(a) && isInt(b):
+		return op.compareInt(a.Int(), b.Int())
+	case isUint(a) && isUint(b):
+		return op.compareUint(a.Uint(), b.Uint())
+	case isInt(a) && isUint(b):
+		if a.Int() < 0 {
+			return op.compareInt(-1, 0)
+		}
+		return op.compareUint(uint64(a.Int()), b.Uint())
+	case isUint(a) && isInt(b):
+		if b.Int() < 0 {
+			return op.compareInt(0, -1)
+		}
+		return op.compareUint(a.Uint(), uint64(b.Int()))
+	}
+
+	return op.compareFloat(toFloat(a), toFloat(b))
+}
 
-	errorValidation := ErrorValidation{
-		fieldValue:     value,
-		validatorType:  ValidatorFormat,
-		validatorValue: validator,
+// isInt checks if a value is a signed integer
+func isInt(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
 	}
 
-	errorSyntax := ErrorSyntax{
-		expression: validator,
-		near:       string(ValidatorFormat),
-		comment:    "could not find format",
+	return false
+}
+
+// isUint checks if a value is an unsigned integer
+func isUint(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
 	}
 
-	switch kind {
-	case reflect.String:
-		formatTypeMap := getFormatTypeMap()
-		if formatFunc, ok := formatTypeMap[FormatType(validator)]; !ok {
-			return errorSyntax
-		} else if !formatFunc(value.String()) {
-			return errorValidation
-		}
-	default:
-		return errorSyntax
+	return false
+}
+
+// toFloat converts a number into a float
+func toFloat(value reflect.Value) float64 {
+	switch {
+	case isInt(value):
+		return float64(value.Int())
+	case isUint(value):
+		return float64(value.Uint())
 	}
 
-	return nil
+	return value.Float()
 }